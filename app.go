@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"io"
 	"time"
@@ -41,10 +42,13 @@ type appModel struct {
 	urlPrompt     *urlPrompt
 	topbar        topbar
 	playingNow    *playingNow
+	upNext        *upNext
 	downloader    *downloader
 	status        *status
 	datatable     *datatable
 	logging       *logging
+	notifier      *notifier
+	popup         modal
 	errorStyle    lipgloss.Style
 	err           error
 	footerMsg     string
@@ -58,6 +62,7 @@ func newModel(
 	logger io.Reader,
 	ctx context.Context,
 	cancelFn context.CancelFunc,
+	db *sql.DB,
 	queries *database.Queries,
 	cfg *config,
 ) appModel {
@@ -65,6 +70,8 @@ func newModel(
 		return ctx
 	}
 
+	queue := newUpNextQueue()
+
 	return appModel{
 		cancelFn:   cancelFn,
 		keymap:     newKeymap(),
@@ -72,10 +79,22 @@ func newModel(
 		urlPrompt:  newURLPrompt(),
 		topbar:     newTopbar(),
 		playingNow: newPlayingNow(player, getContext),
+		upNext:     newUpNext(queue),
 		downloader: downloader,
 		status:     newStatus(cfg.DownloadPath),
-		datatable:  newDatatable(player, queries, getContext),
+		datatable: newDatatable(
+			player,
+			db,
+			queries,
+			newClipper(cfg),
+			queue,
+			cfg.DownloadPath,
+			newRetentionPolicy(cfg),
+			cfg.HistoryDepth,
+			getContext,
+		),
 		logging:    newLogging(logger),
+		notifier:   newNotifier(cfg, db, queries, getContext),
 		errorStyle: newErrorStyle(),
 	}
 }
@@ -87,6 +106,7 @@ func (m appModel) Init() tea.Cmd {
 		m.status.Init(),
 		m.datatable.Init(),
 		m.playingNow.Init(),
+		m.upNext.Init(),
 		m.logging.Init(),
 		sectionChangedCmd(sectionDatatable),
 	)
@@ -108,6 +128,21 @@ func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.popup != nil {
+			var cmd tea.Cmd
+			m.popup, cmd = m.popup.Update(msg)
+
+			return m, cmd
+		}
+
+		// the search input takes every key, including the global ones
+		if m.datatable.isSearching() {
+			var cmd tea.Cmd
+			m.datatable, cmd = m.datatable.Update(msg)
+
+			return m, cmd
+		}
+
 		switch {
 		case key.Matches(msg, m.keymap.help):
 			m.help.ShowAll = !m.help.ShowAll
@@ -121,10 +156,18 @@ func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.logging.visible = !m.logging.visible
 			return m, nil
 		}
+	case requestQuitMsg:
+		return m, m.exitCmd()
 	case sectionChangedMsg:
 		m.section = msg.section
+	case openPopupMsg:
+		m.popup = msg.popup
+	case closePopupMsg:
+		m.popup = nil
 	case submitURLMsg:
-		cmds = append(cmds, enqueueURLCmd(m.downloader, msg.url))
+		cmds = append(cmds, enqueueCmd(m.downloader, downloadRequest{url: msg.url, start: msg.start}))
+	case redownloadMsg:
+		cmds = append(cmds, enqueueCmd(m.downloader, msg.req))
 	case tea.WindowSizeMsg:
 		m.width, m.height = msg.Width, msg.Height
 	case footerMsg:
@@ -143,16 +186,26 @@ func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 
 	var cmd tea.Cmd
+
+	if m.popup != nil {
+		m.popup, cmd = m.popup.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+
 	m.urlPrompt, cmd = m.urlPrompt.Update(msg)
 	cmds = append(cmds, cmd)
 	m.topbar, cmd = m.topbar.Update(msg)
 	cmds = append(cmds, cmd)
 	m.playingNow, cmd = m.playingNow.Update(msg)
 	cmds = append(cmds, cmd)
+	m.upNext, cmd = m.upNext.Update(msg)
+	cmds = append(cmds, cmd)
 	m.status, cmd = m.status.Update(msg)
 	cmds = append(cmds, cmd)
 	m.logging, cmd = m.logging.Update(msg)
 	cmds = append(cmds, cmd)
+	m.notifier, cmd = m.notifier.Update(msg)
+	cmds = append(cmds, cmd)
 	m.datatable, cmd = m.datatable.Update(msg)
 	cmds = append(cmds, cmd)
 
@@ -43,6 +43,12 @@ func (m appModel) footerView() string {
 		keymap = m.keymap.prompt
 	case sectionDatatable:
 		keymap = m.keymap.datatable
+	case sectionUpNext:
+		keymap = m.keymap.upNext
+	}
+
+	if m.popup != nil {
+		keymap = m.popup.helpKeymap()
 	}
 
 	m.help.Width = m.width
@@ -81,6 +87,18 @@ func (m appModel) footerView() string {
 	return ""
 }
 
+func (m appModel) popupView(height int) string {
+	const maxPopupWidth = 80
+
+	return lipgloss.Place(
+		m.width,
+		height,
+		lipgloss.Center,
+		lipgloss.Center,
+		m.popup.View(min(m.width, maxPopupWidth), height),
+	)
+}
+
 func (m appModel) calculateHeights(components []string) int {
 	var totalHeight int
 
@@ -103,19 +121,25 @@ func (m appModel) View() string {
 	}
 
 	const datatableIdx = 2
-	const totalSections = 6
+	const totalSections = 7
 
 	sections := make([]string, 0, totalSections)
 	sections = append(sections, m.topbar.View())
 	sections = append(sections, m.urlPrompt.View())
 	sections = append(sections, m.playingNow.View())
+	sections = append(sections, m.upNext.View())
 	sections = append(sections, m.status.View())
 	sections = append(sections, m.logging.View())
 	sections = append(sections, m.footerView())
 
 	heightAdjusted := m.height - m.calculateHeights(sections)
 	m.datatable.setHeight(heightAdjusted)
-	sections = slices.Insert(sections, datatableIdx, m.datatable.View())
+
+	if m.popup != nil {
+		sections = slices.Insert(sections, datatableIdx, m.popupView(heightAdjusted))
+	} else {
+		sections = slices.Insert(sections, datatableIdx, m.datatable.View())
+	}
 
 	return lipgloss.JoinVertical(lipgloss.Center, slices.DeleteFunc(sections, func(c string) bool {
 		return c == ""
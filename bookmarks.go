@@ -0,0 +1,133 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/linnovs/ytqueue/database"
+)
+
+// exportBookmarksItem is the value of the popup item exporting the bookmarks.
+type exportBookmarksItem struct{}
+
+func bookmarkPosition(b database.Bookmark) time.Duration {
+	return time.Duration(b.Position * float64(time.Second))
+}
+
+// addBookmarkCmd bookmarks the current position of the playing video, the
+// position is taken when the key is pressed and not when the note is saved.
+func (d *datatable) addBookmarkCmd() tea.Cmd {
+	id := d.player.getCurrentlyPlayingId()
+	if !d.player.isRunning() || id == "" {
+		return footerMsgCmd("Nothing is playing", 0)
+	}
+
+	position := d.player.getPlaytime()
+	form := newFormPopup(
+		"Bookmark at "+formatPlaytime(position),
+		[]formField{{label: "Note (optional)"}},
+		func(values []string) tea.Cmd {
+			return func() tea.Msg {
+				if err := d.datastore.addBookmark(d.getCtx(), id, position, values[0]); err != nil {
+					return errorMsg{fmt.Errorf("failed to add bookmark: %w", err)}
+				}
+
+				return footerMsgCmd("Bookmarked at "+formatPlaytime(position), 0)()
+			}
+		},
+	)
+
+	return openFormPopupCmd(form)
+}
+
+// bookmarksPopupCmd lists the bookmarks of the video at cursor.
+func (d *datatable) bookmarksPopupCmd(cursor int) tea.Cmd {
+	return func() tea.Msg {
+		rows := d.getCopyOfRows()
+		if cursor < 0 || cursor >= len(rows) {
+			return nil
+		}
+
+		r := rows[cursor]
+
+		bookmarks, err := d.datastore.getBookmarks(d.getCtx(), r[colID])
+		if err != nil {
+			return errorMsg{fmt.Errorf("failed to load bookmarks: %w", err)}
+		}
+
+		items := make([]popupItem, 0, len(bookmarks)+1)
+		for _, b := range bookmarks {
+			items = append(items, popupItem{
+				label: strings.TrimSpace(formatPlaytime(bookmarkPosition(b)) + "  " + b.Note),
+				value: b,
+			})
+		}
+
+		if len(bookmarks) != 0 {
+			items = append(items, popupItem{
+				label: "Export to Markdown",
+				value: exportBookmarksItem{},
+			})
+		}
+
+		return openPopupMsg{newPopup("Bookmarks: "+r[colName], items, func(item popupItem) tea.Cmd {
+			switch v := item.value.(type) {
+			case database.Bookmark:
+				return d.playAtCmd(r[colID], bookmarkPosition(v))
+			case exportBookmarksItem:
+				return exportBookmarksCmd(r, bookmarks)
+			}
+
+			return nil
+		})}
+	}
+}
+
+func renderBookmarksMarkdown(r row, bookmarks []database.Bookmark) string {
+	var s strings.Builder
+
+	title := strings.TrimSuffix(r[colName], filepath.Ext(r[colName]))
+	fmt.Fprintf(&s, "# %s\n\n<%s>\n\n", title, r[colURL])
+
+	for _, b := range bookmarks {
+		position := bookmarkPosition(b)
+		fmt.Fprintf(&s, "- [%s](%s)", formatPlaytime(position), timestampedURL(r[colURL], position))
+
+		if b.Note != "" {
+			s.WriteString(" " + b.Note)
+		}
+
+		s.WriteString("\n")
+	}
+
+	return s.String()
+}
+
+// exportBookmarksCmd writes the bookmarks as Markdown next to the video file.
+func exportBookmarksCmd(r row, bookmarks []database.Bookmark) tea.Cmd {
+	return func() tea.Msg {
+		const filePerm = 0o644
+
+		if len(bookmarks) == 0 {
+			return errorMsg{errors.New("no bookmarks to export")}
+		}
+
+		name := strings.TrimSuffix(r[colName], filepath.Ext(r[colName])) + ".bookmarks.md"
+		path := filepath.Join(r[colLocation], name)
+
+		content := renderBookmarksMarkdown(r, bookmarks)
+
+		if err := os.WriteFile(path, []byte(content), filePerm); err != nil {
+			return errorMsg{fmt.Errorf("failed to export bookmarks: %w", err)}
+		}
+
+		const msgDelay = 5 * time.Second
+
+		return footerMsgCmd("Exported bookmarks to "+path, msgDelay)()
+	}
+}
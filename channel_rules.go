@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/linnovs/ytqueue/database"
+)
+
+const (
+	maxChannelRuleSpeed  = 4
+	channelLookupTimeout = 30 * time.Second
+)
+
+var errUnknownChannel = errors.New("channel is unknown for this video")
+
+// lookupChannel asks yt-dlp for the channel of a video downloaded before the
+// channel was kept, clips are looked up by the URL of their video.
+func lookupChannel(ctx context.Context, url string) (string, error) {
+	url, _, _ = strings.Cut(url, "#")
+	if !strings.HasPrefix(url, "http") {
+		return "", errUnknownChannel
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, channelLookupTimeout)
+	defer cancel()
+
+	out, err := exec.CommandContext( // #nosec G204
+		ctx,
+		"yt-dlp",
+		"--skip-download",
+		"--no-warnings",
+		"--print",
+		"%(channel)s",
+		url,
+	).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to look up channel: %w", err)
+	}
+
+	channel := strings.TrimSpace(string(out))
+	if channel == "" || channel == "NA" {
+		return "", errUnknownChannel
+	}
+
+	return channel, nil
+}
+
+// backfillChannel looks up and keeps the channel of the row, the row is
+// updated in place.
+func (d *datatable) backfillChannel(r row) (string, error) {
+	channel, err := lookupChannel(d.getCtx(), r[colURL])
+	if err != nil {
+		return "", err
+	}
+
+	video, err := d.datastore.setChannel(d.getCtx(), r[colID], channel)
+	if err != nil {
+		return "", fmt.Errorf("failed to save channel: %w", err)
+	}
+
+	rows := d.getCopyOfRows()
+	if idx := slices.IndexFunc(rows, playingIDIndexFunc(r[colID])); idx >= 0 {
+		rows[idx] = updatedRow(rows[idx], *video)
+		d.setRows(rows)
+	}
+
+	return channel, nil
+}
+
+func formatRuleValue(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// parseChannelRule validates the values of the channel rule form, an empty
+// value means the default.
+func parseChannelRule(channel string, values []string) (database.ChannelRule, error) {
+	rule := database.ChannelRule{Channel: channel, Speed: 1}
+	fields := []struct {
+		name string
+		dst  *float64
+		min  float64
+		max  float64
+	}{
+		{"intro", &rule.StartOffset, 0, math.MaxFloat64},
+		{"outro", &rule.EndOffset, 0, math.MaxFloat64},
+		{"speed", &rule.Speed, 0.1, maxChannelRuleSpeed},
+	}
+
+	for i, field := range fields {
+		if values[i] == "" {
+			continue
+		}
+
+		v, err := strconv.ParseFloat(values[i], 64)
+		if err != nil || v < field.min || v > field.max {
+			return rule, fmt.Errorf("invalid %s value %q", field.name, values[i])
+		}
+
+		*field.dst = v
+	}
+
+	return rule, nil
+}
+
+func (d *datatable) saveChannelRuleCmd(channel string) formSubmitFn {
+	return func(values []string) tea.Cmd {
+		return func() tea.Msg {
+			rule, err := parseChannelRule(channel, values)
+			if err != nil {
+				return errorMsg{err}
+			}
+
+			if rule.StartOffset == 0 && rule.EndOffset == 0 && rule.Speed == 1 {
+				if err := d.datastore.deleteChannelRule(d.getCtx(), channel); err != nil {
+					return errorMsg{fmt.Errorf("failed to delete channel rule: %w", err)}
+				}
+
+				return footerMsgCmd("Removed playback rule of "+channel, 0)()
+			}
+
+			if err := d.datastore.setChannelRule(d.getCtx(), rule); err != nil {
+				return errorMsg{fmt.Errorf("failed to save channel rule: %w", err)}
+			}
+
+			return footerMsgCmd("Saved playback rule of "+channel, 0)()
+		}
+	}
+}
+
+// channelRulePopupCmd opens the form editing the playback rule of the channel
+// the video at cursor belongs to, it applies from the next played video.
+func (d *datatable) channelRulePopupCmd(cursor int) tea.Cmd {
+	return func() tea.Msg {
+		rows := d.getCopyOfRows()
+		if cursor < 0 || cursor >= len(rows) {
+			return nil
+		}
+
+		channel := rows[cursor][colChannel]
+		if channel == "" {
+			var err error
+			if channel, err = d.backfillChannel(rows[cursor]); err != nil {
+				return errorMsg{err}
+			}
+		}
+
+		rule, err := d.datastore.getChannelRule(d.getCtx(), channel)
+		if err != nil {
+			return errorMsg{fmt.Errorf("failed to load channel rule: %w", err)}
+		}
+
+		if rule == nil {
+			rule = &database.ChannelRule{Channel: channel, Speed: 1}
+		}
+
+		form := newFormPopup("Playback rule: "+channel, []formField{
+			{label: "Skip intro (seconds)", value: formatRuleValue(rule.StartOffset)},
+			{label: "Skip outro (seconds)", value: formatRuleValue(rule.EndOffset)},
+			{label: "Default speed", value: formatRuleValue(rule.Speed)},
+		}, d.saveChannelRuleCmd(channel))
+
+		return openFormPopupCmd(form)()
+	}
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// clipper cuts A/B ranges out of videos with ffmpeg into the library.
+type clipper struct {
+	libraryDir string
+	reencode   bool
+}
+
+func newClipper(cfg *config) *clipper {
+	return &clipper{
+		libraryDir: cfg.DownloadPath,
+		reencode:   cfg.ReencodeClips,
+	}
+}
+
+// clipName names the clip of name from from to to, re-encoded clips are
+// always mp4.
+func (c *clipper) clipName(name string, from, to time.Duration) string {
+	ext := filepath.Ext(name)
+	if c.reencode {
+		ext = ".mp4"
+	}
+
+	return fmt.Sprintf(
+		"%s (clip %s-%s)%s",
+		strings.TrimSuffix(name, filepath.Ext(name)),
+		fileTimestamp(from),
+		fileTimestamp(to),
+		ext,
+	)
+}
+
+// clipURL gives the clip a URL of its own pointing at the range of the source,
+// in media fragment syntax.
+func clipURL(url string, from, to time.Duration) string {
+	url, _, _ = strings.Cut(url, "#")
+
+	return fmt.Sprintf("%s#t=%.3f,%.3f", url, from.Seconds(), to.Seconds())
+}
+
+// args seeks the input so the cut is quick, stream copying then starts the
+// clip at the keyframe before from.
+func (c *clipper) args(src, dst string, from, to time.Duration) []string {
+	args := []string{
+		"-hide_banner",
+		"-loglevel", "error",
+		"-nostdin",
+		"-n",
+		"-ss", fmt.Sprintf("%.3f", from.Seconds()),
+		"-to", fmt.Sprintf("%.3f", to.Seconds()),
+		"-i", src,
+		"-map", "0:v?",
+		"-map", "0:a?",
+	}
+
+	if c.reencode {
+		args = append(args, "-c:v", "libx264", "-preset", "veryfast", "-c:a", "aac")
+	} else {
+		args = append(args, "-c", "copy", "-avoid_negative_ts", "make_zero")
+	}
+
+	return append(args, dst)
+}
+
+// cut writes the clip of src into the library and returns its file name.
+func (c *clipper) cut(ctx context.Context, src string, from, to time.Duration) (string, error) {
+	name := c.clipName(filepath.Base(src), from, to)
+	dst := filepath.Join(c.libraryDir, name)
+
+	if _, err := os.Stat(dst); err == nil {
+		return "", fmt.Errorf("clip %q already exists", name)
+	}
+
+	var stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, "ffmpeg", c.args(src, dst, from, to)...) // #nosec G204
+	cmd.Stderr = &stderr
+
+	slog.Debug("executing clip command", slog.String("command", cmd.String()))
+
+	if err := cmd.Run(); err != nil {
+		if err := os.Remove(dst); err != nil && !errors.Is(err, os.ErrNotExist) {
+			slog.Error("unable to remove partial clip", slog.String("error", err.Error()))
+		}
+
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%w: %s", err, msg)
+		}
+
+		return "", err
+	}
+
+	return name, nil
+}
+
+type clipExportedMsg struct{ name string }
+
+// exportClipCmd cuts the marked range of the video playing in the background
+// and adds the clip to the table linked to its source.
+func (d *datatable) exportClipCmd() tea.Cmd {
+	id := d.player.getCurrentlyPlayingId()
+
+	from, to, ok := d.player.getClipRange()
+	if !ok || id == "" {
+		return footerMsgCmd("Mark A and B with A first", 0)
+	}
+
+	rows := d.getCopyOfRows()
+
+	idx := slices.IndexFunc(rows, playingIDIndexFunc(id))
+	if idx == -1 {
+		return errorCmd(errors.New("playing video not found in datatable"))
+	}
+
+	source := rows[idx]
+
+	return tea.Batch(footerMsgCmd("Exporting clip...", 0), func() tea.Msg {
+		sourceID, err := idStrToInt(id)
+		if err != nil {
+			return errorMsg{err}
+		}
+
+		src := filepath.Join(source[colLocation], source[colName])
+
+		name, err := d.clipper.cut(d.getCtx(), src, from, to)
+		if err != nil {
+			return errorMsg{fmt.Errorf("failed to export clip: %w", err)}
+		}
+
+		video, err := d.datastore.addVideo(d.getCtx(), newVideo{
+			name:       name,
+			url:        clipURL(source[colURL], from, to),
+			location:   d.clipper.libraryDir,
+			duration:   (to - from).Seconds(),
+			sourceID:   sourceID,
+			playlistID: d.getPlaylist().ID,
+		})
+		if err != nil {
+			return errorMsg{err}
+		}
+
+		d.prependRow(*video)
+
+		return clipExportedMsg{name}
+	})
+}
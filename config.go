@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/adrg/xdg"
 	"github.com/knadh/koanf/parsers/toml"
@@ -13,11 +14,30 @@ import (
 )
 
 type config struct {
-	DownloadPath   string `koanf:"download.path"`
-	TempName       string `koanf:"download.temp_name"`
-	UserAgent      string `koanf:"download.user_agent"`
-	BrowserCookies string `koanf:"download.browser_cookies"`
-	tempDir        string
+	DownloadPath   string   `koanf:"download.path"`
+	TempName       string   `koanf:"download.temp_name"`
+	UserAgent      string   `koanf:"download.user_agent"`
+	BrowserCookies string   `koanf:"download.browser_cookies"`
+	SponsorBlock   bool     `koanf:"sponsorblock.enabled"`
+	SkipCategories []string `koanf:"sponsorblock.skip"`
+	SubLanguages   []string `koanf:"subtitles.languages"`
+	AutoSubs       bool     `koanf:"subtitles.auto"`
+	EmbedSubs      bool     `koanf:"subtitles.embed"`
+	ScreenshotPath string   `koanf:"screenshots.path"`
+	ReencodeClips  bool     `koanf:"clips.reencode"`
+	HistoryDepth   int      `koanf:"history.depth"`
+
+	RetentionWatchedDays int           `koanf:"retention.watched_days"`
+	RetentionMaxSizeGiB  float64       `koanf:"retention.max_size_gib"`
+	RetentionMovePath    string        `koanf:"retention.move_path"`
+	RetentionInterval    time.Duration `koanf:"retention.interval"`
+
+	NotifyDownloadFinished bool `koanf:"notifications.download_finished"`
+	NotifyDownloadFailed   bool `koanf:"notifications.download_failed"`
+	NotifyQueueDrained     bool `koanf:"notifications.queue_drained"`
+	NotifyNowPlaying       bool `koanf:"notifications.now_playing"`
+
+	tempDir string
 }
 
 func loadConfig() (*config, error) {
@@ -41,20 +61,59 @@ func loadConfig() (*config, error) {
 		cfg.DownloadPath = "~/Downloads"
 	}
 
-	var isHome bool
+	cfg.DownloadPath = expandHome(cfg.DownloadPath)
 
-	if cfg.DownloadPath, isHome = strings.CutPrefix(cfg.DownloadPath, "~"); isHome {
-		cfg.DownloadPath = filepath.Join(xdg.Home, cfg.DownloadPath)
+	if cfg.ScreenshotPath == "" {
+		cfg.ScreenshotPath = filepath.Join(xdg.UserDirs.Pictures, "ytqueue")
 	}
 
+	cfg.ScreenshotPath = expandHome(cfg.ScreenshotPath)
+
 	if cfg.TempName == "" {
 		cfg.TempName = "ytqueue_temp"
 	}
 
+	if !k.Exists("history.depth") {
+		cfg.HistoryDepth = defaultHistoryDepth
+	}
+
+	if cfg.RetentionInterval <= 0 {
+		cfg.RetentionInterval = defaultRetentionInterval
+	}
+
+	if cfg.RetentionMovePath != "" {
+		cfg.RetentionMovePath = expandHome(cfg.RetentionMovePath)
+
+		// videos moved within the library would be moved again and again
+		if isInDir(cfg.DownloadPath, cfg.RetentionMovePath) {
+			return nil, errRetentionMoveInLibrary
+		}
+	}
+
+	if !k.Exists("sponsorblock.skip") {
+		cfg.SkipCategories = []string{"sponsor", "selfpromo", "intro", "outro"}
+	}
+
+	for key, enabled := range map[string]*bool{
+		"notifications.download_finished": &cfg.NotifyDownloadFinished,
+		"notifications.download_failed":   &cfg.NotifyDownloadFailed,
+		"notifications.queue_drained":     &cfg.NotifyQueueDrained,
+	} {
+		if !k.Exists(key) {
+			*enabled = true
+		}
+	}
+
 	const filePerm = 0o744
 
-	if err := os.MkdirAll(cfg.DownloadPath, os.ModeDir|filePerm); err != nil {
-		return nil, err
+	for _, dir := range []string{cfg.DownloadPath, cfg.ScreenshotPath, cfg.RetentionMovePath} {
+		if dir == "" {
+			continue
+		}
+
+		if err := os.MkdirAll(dir, os.ModeDir|filePerm); err != nil {
+			return nil, err
+		}
 	}
 
 	cfg.tempDir, err = os.MkdirTemp(os.TempDir(), cfg.TempName)
@@ -65,6 +124,15 @@ func loadConfig() (*config, error) {
 	return cfg, nil
 }
 
+// expandHome replaces a leading ~ with the home directory.
+func expandHome(path string) string {
+	if path, isHome := strings.CutPrefix(path, "~"); isHome {
+		return filepath.Join(xdg.Home, path)
+	}
+
+	return path
+}
+
 func cleanupTempDir(tempDir string) {
 	if err := os.RemoveAll(tempDir); err != nil {
 		slog.Error("unable to remove temp dir", slog.String("error", err.Error()))
@@ -24,57 +24,497 @@ func New(db DBTX) *Queries {
 func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	q := Queries{db: db}
 	var err error
+	if q.addBookmarkStmt, err = db.PrepareContext(ctx, addBookmark); err != nil {
+		return nil, fmt.Errorf("error preparing query AddBookmark: %w", err)
+	}
+	if q.addHistoryEntryStmt, err = db.PrepareContext(ctx, addHistoryEntry); err != nil {
+		return nil, fmt.Errorf("error preparing query AddHistoryEntry: %w", err)
+	}
+	if q.addPlaylistStmt, err = db.PrepareContext(ctx, addPlaylist); err != nil {
+		return nil, fmt.Errorf("error preparing query AddPlaylist: %w", err)
+	}
+	if q.addPlaylistVideoStmt, err = db.PrepareContext(ctx, addPlaylistVideo); err != nil {
+		return nil, fmt.Errorf("error preparing query AddPlaylistVideo: %w", err)
+	}
+	if q.addSegmentStmt, err = db.PrepareContext(ctx, addSegment); err != nil {
+		return nil, fmt.Errorf("error preparing query AddSegment: %w", err)
+	}
+	if q.addSubtitleStmt, err = db.PrepareContext(ctx, addSubtitle); err != nil {
+		return nil, fmt.Errorf("error preparing query AddSubtitle: %w", err)
+	}
+	if q.addSubtitleCueStmt, err = db.PrepareContext(ctx, addSubtitleCue); err != nil {
+		return nil, fmt.Errorf("error preparing query AddSubtitleCue: %w", err)
+	}
+	if q.addTagStmt, err = db.PrepareContext(ctx, addTag); err != nil {
+		return nil, fmt.Errorf("error preparing query AddTag: %w", err)
+	}
 	if q.addVideoStmt, err = db.PrepareContext(ctx, addVideo); err != nil {
 		return nil, fmt.Errorf("error preparing query AddVideo: %w", err)
 	}
+	if q.clearResumePositionStmt, err = db.PrepareContext(ctx, clearResumePosition); err != nil {
+		return nil, fmt.Errorf("error preparing query ClearResumePosition: %w", err)
+	}
+	if q.deleteChannelRuleStmt, err = db.PrepareContext(ctx, deleteChannelRule); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteChannelRule: %w", err)
+	}
+	if q.deletePlaylistStmt, err = db.PrepareContext(ctx, deletePlaylist); err != nil {
+		return nil, fmt.Errorf("error preparing query DeletePlaylist: %w", err)
+	}
+	if q.deleteSegmentsStmt, err = db.PrepareContext(ctx, deleteSegments); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteSegments: %w", err)
+	}
+	if q.deleteSubtitleCuesStmt, err = db.PrepareContext(ctx, deleteSubtitleCues); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteSubtitleCues: %w", err)
+	}
+	if q.deleteSubtitlesStmt, err = db.PrepareContext(ctx, deleteSubtitles); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteSubtitles: %w", err)
+	}
+	if q.deleteTrashedVideoByURLStmt, err = db.PrepareContext(ctx, deleteTrashedVideoByURL); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteTrashedVideoByURL: %w", err)
+	}
+	if q.deleteUndoneHistoryStmt, err = db.PrepareContext(ctx, deleteUndoneHistory); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteUndoneHistory: %w", err)
+	}
+	if q.deleteUnusedTagsStmt, err = db.PrepareContext(ctx, deleteUnusedTags); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteUnusedTags: %w", err)
+	}
 	if q.deleteVideoStmt, err = db.PrepareContext(ctx, deleteVideo); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteVideo: %w", err)
 	}
+	if q.getBookmarksStmt, err = db.PrepareContext(ctx, getBookmarks); err != nil {
+		return nil, fmt.Errorf("error preparing query GetBookmarks: %w", err)
+	}
+	if q.getChannelRuleStmt, err = db.PrepareContext(ctx, getChannelRule); err != nil {
+		return nil, fmt.Errorf("error preparing query GetChannelRule: %w", err)
+	}
+	if q.getFirstUndoneHistoryEntryStmt, err = db.PrepareContext(ctx, getFirstUndoneHistoryEntry); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFirstUndoneHistoryEntry: %w", err)
+	}
+	if q.getLastDoneHistoryEntryStmt, err = db.PrepareContext(ctx, getLastDoneHistoryEntry); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLastDoneHistoryEntry: %w", err)
+	}
+	if q.getPlaylistStmt, err = db.PrepareContext(ctx, getPlaylist); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPlaylist: %w", err)
+	}
+	if q.getPlaylistVideoIDsByRankStmt, err = db.PrepareContext(ctx, getPlaylistVideoIDsByRank); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPlaylistVideoIDsByRank: %w", err)
+	}
+	if q.getPlaylistVideoRankStmt, err = db.PrepareContext(ctx, getPlaylistVideoRank); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPlaylistVideoRank: %w", err)
+	}
+	if q.getPlaylistVideosStmt, err = db.PrepareContext(ctx, getPlaylistVideos); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPlaylistVideos: %w", err)
+	}
+	if q.getPlaylistsStmt, err = db.PrepareContext(ctx, getPlaylists); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPlaylists: %w", err)
+	}
+	if q.getSegmentsStmt, err = db.PrepareContext(ctx, getSegments); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSegments: %w", err)
+	}
+	if q.getSettingStmt, err = db.PrepareContext(ctx, getSetting); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSetting: %w", err)
+	}
+	if q.getSubtitlesStmt, err = db.PrepareContext(ctx, getSubtitles); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSubtitles: %w", err)
+	}
+	if q.getTopPlaylistVideoIDStmt, err = db.PrepareContext(ctx, getTopPlaylistVideoID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTopPlaylistVideoID: %w", err)
+	}
+	if q.getTopVideoIDStmt, err = db.PrepareContext(ctx, getTopVideoID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTopVideoID: %w", err)
+	}
+	if q.getTrashedVideosStmt, err = db.PrepareContext(ctx, getTrashedVideos); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTrashedVideos: %w", err)
+	}
+	if q.getUnindexedSubtitlesStmt, err = db.PrepareContext(ctx, getUnindexedSubtitles); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUnindexedSubtitles: %w", err)
+	}
+	if q.getVideoStmt, err = db.PrepareContext(ctx, getVideo); err != nil {
+		return nil, fmt.Errorf("error preparing query GetVideo: %w", err)
+	}
+	if q.getVideoIDsByRankStmt, err = db.PrepareContext(ctx, getVideoIDsByRank); err != nil {
+		return nil, fmt.Errorf("error preparing query GetVideoIDsByRank: %w", err)
+	}
+	if q.getVideoRankStmt, err = db.PrepareContext(ctx, getVideoRank); err != nil {
+		return nil, fmt.Errorf("error preparing query GetVideoRank: %w", err)
+	}
+	if q.getVideoTagsStmt, err = db.PrepareContext(ctx, getVideoTags); err != nil {
+		return nil, fmt.Errorf("error preparing query GetVideoTags: %w", err)
+	}
 	if q.getVideosStmt, err = db.PrepareContext(ctx, getVideos); err != nil {
 		return nil, fmt.Errorf("error preparing query GetVideos: %w", err)
 	}
+	if q.getVideosWithoutSizeStmt, err = db.PrepareContext(ctx, getVideosWithoutSize); err != nil {
+		return nil, fmt.Errorf("error preparing query GetVideosWithoutSize: %w", err)
+	}
+	if q.relinkVideoStmt, err = db.PrepareContext(ctx, relinkVideo); err != nil {
+		return nil, fmt.Errorf("error preparing query RelinkVideo: %w", err)
+	}
+	if q.removePlaylistVideoStmt, err = db.PrepareContext(ctx, removePlaylistVideo); err != nil {
+		return nil, fmt.Errorf("error preparing query RemovePlaylistVideo: %w", err)
+	}
+	if q.restoreVideoStmt, err = db.PrepareContext(ctx, restoreVideo); err != nil {
+		return nil, fmt.Errorf("error preparing query RestoreVideo: %w", err)
+	}
+	if q.searchSubtitleCuesStmt, err = db.PrepareContext(ctx, searchSubtitleCues); err != nil {
+		return nil, fmt.Errorf("error preparing query SearchSubtitleCues: %w", err)
+	}
+	if q.setChannelRuleStmt, err = db.PrepareContext(ctx, setChannelRule); err != nil {
+		return nil, fmt.Errorf("error preparing query SetChannelRule: %w", err)
+	}
+	if q.setHistoryEntryUndoneStmt, err = db.PrepareContext(ctx, setHistoryEntryUndone); err != nil {
+		return nil, fmt.Errorf("error preparing query SetHistoryEntryUndone: %w", err)
+	}
+	if q.setSettingStmt, err = db.PrepareContext(ctx, setSetting); err != nil {
+		return nil, fmt.Errorf("error preparing query SetSetting: %w", err)
+	}
+	if q.setVideoChannelStmt, err = db.PrepareContext(ctx, setVideoChannel); err != nil {
+		return nil, fmt.Errorf("error preparing query SetVideoChannel: %w", err)
+	}
+	if q.setVideoKeepStmt, err = db.PrepareContext(ctx, setVideoKeep); err != nil {
+		return nil, fmt.Errorf("error preparing query SetVideoKeep: %w", err)
+	}
+	if q.setVideoSizeStmt, err = db.PrepareContext(ctx, setVideoSize); err != nil {
+		return nil, fmt.Errorf("error preparing query SetVideoSize: %w", err)
+	}
+	if q.setVideoWatchedStmt, err = db.PrepareContext(ctx, setVideoWatched); err != nil {
+		return nil, fmt.Errorf("error preparing query SetVideoWatched: %w", err)
+	}
 	if q.setWatchedVideoStmt, err = db.PrepareContext(ctx, setWatchedVideo); err != nil {
 		return nil, fmt.Errorf("error preparing query SetWatchedVideo: %w", err)
 	}
+	if q.tagVideoStmt, err = db.PrepareContext(ctx, tagVideo); err != nil {
+		return nil, fmt.Errorf("error preparing query TagVideo: %w", err)
+	}
+	if q.toggleVideoKeepStmt, err = db.PrepareContext(ctx, toggleVideoKeep); err != nil {
+		return nil, fmt.Errorf("error preparing query ToggleVideoKeep: %w", err)
+	}
 	if q.toggleWatchedStatusStmt, err = db.PrepareContext(ctx, toggleWatchedStatus); err != nil {
 		return nil, fmt.Errorf("error preparing query ToggleWatchedStatus: %w", err)
 	}
-	if q.updateVideoOrderStmt, err = db.PrepareContext(ctx, updateVideoOrder); err != nil {
-		return nil, fmt.Errorf("error preparing query UpdateVideoOrder: %w", err)
+	if q.trashVideoStmt, err = db.PrepareContext(ctx, trashVideo); err != nil {
+		return nil, fmt.Errorf("error preparing query TrashVideo: %w", err)
+	}
+	if q.trimHistoryStmt, err = db.PrepareContext(ctx, trimHistory); err != nil {
+		return nil, fmt.Errorf("error preparing query TrimHistory: %w", err)
+	}
+	if q.untagVideoStmt, err = db.PrepareContext(ctx, untagVideo); err != nil {
+		return nil, fmt.Errorf("error preparing query UntagVideo: %w", err)
+	}
+	if q.updatePlaylistVideoRankStmt, err = db.PrepareContext(ctx, updatePlaylistVideoRank); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdatePlaylistVideoRank: %w", err)
+	}
+	if q.updateVideoRankStmt, err = db.PrepareContext(ctx, updateVideoRank); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateVideoRank: %w", err)
 	}
 	return &q, nil
 }
 
 func (q *Queries) Close() error {
 	var err error
+	if q.addBookmarkStmt != nil {
+		if cerr := q.addBookmarkStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addBookmarkStmt: %w", cerr)
+		}
+	}
+	if q.addHistoryEntryStmt != nil {
+		if cerr := q.addHistoryEntryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addHistoryEntryStmt: %w", cerr)
+		}
+	}
+	if q.addPlaylistStmt != nil {
+		if cerr := q.addPlaylistStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addPlaylistStmt: %w", cerr)
+		}
+	}
+	if q.addPlaylistVideoStmt != nil {
+		if cerr := q.addPlaylistVideoStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addPlaylistVideoStmt: %w", cerr)
+		}
+	}
+	if q.addSegmentStmt != nil {
+		if cerr := q.addSegmentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addSegmentStmt: %w", cerr)
+		}
+	}
+	if q.addSubtitleStmt != nil {
+		if cerr := q.addSubtitleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addSubtitleStmt: %w", cerr)
+		}
+	}
+	if q.addSubtitleCueStmt != nil {
+		if cerr := q.addSubtitleCueStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addSubtitleCueStmt: %w", cerr)
+		}
+	}
+	if q.addTagStmt != nil {
+		if cerr := q.addTagStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addTagStmt: %w", cerr)
+		}
+	}
 	if q.addVideoStmt != nil {
 		if cerr := q.addVideoStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing addVideoStmt: %w", cerr)
 		}
 	}
+	if q.clearResumePositionStmt != nil {
+		if cerr := q.clearResumePositionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing clearResumePositionStmt: %w", cerr)
+		}
+	}
+	if q.deleteChannelRuleStmt != nil {
+		if cerr := q.deleteChannelRuleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteChannelRuleStmt: %w", cerr)
+		}
+	}
+	if q.deletePlaylistStmt != nil {
+		if cerr := q.deletePlaylistStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deletePlaylistStmt: %w", cerr)
+		}
+	}
+	if q.deleteSegmentsStmt != nil {
+		if cerr := q.deleteSegmentsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteSegmentsStmt: %w", cerr)
+		}
+	}
+	if q.deleteSubtitleCuesStmt != nil {
+		if cerr := q.deleteSubtitleCuesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteSubtitleCuesStmt: %w", cerr)
+		}
+	}
+	if q.deleteSubtitlesStmt != nil {
+		if cerr := q.deleteSubtitlesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteSubtitlesStmt: %w", cerr)
+		}
+	}
+	if q.deleteTrashedVideoByURLStmt != nil {
+		if cerr := q.deleteTrashedVideoByURLStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteTrashedVideoByURLStmt: %w", cerr)
+		}
+	}
+	if q.deleteUndoneHistoryStmt != nil {
+		if cerr := q.deleteUndoneHistoryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteUndoneHistoryStmt: %w", cerr)
+		}
+	}
+	if q.deleteUnusedTagsStmt != nil {
+		if cerr := q.deleteUnusedTagsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteUnusedTagsStmt: %w", cerr)
+		}
+	}
 	if q.deleteVideoStmt != nil {
 		if cerr := q.deleteVideoStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteVideoStmt: %w", cerr)
 		}
 	}
+	if q.getBookmarksStmt != nil {
+		if cerr := q.getBookmarksStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getBookmarksStmt: %w", cerr)
+		}
+	}
+	if q.getChannelRuleStmt != nil {
+		if cerr := q.getChannelRuleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getChannelRuleStmt: %w", cerr)
+		}
+	}
+	if q.getFirstUndoneHistoryEntryStmt != nil {
+		if cerr := q.getFirstUndoneHistoryEntryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFirstUndoneHistoryEntryStmt: %w", cerr)
+		}
+	}
+	if q.getLastDoneHistoryEntryStmt != nil {
+		if cerr := q.getLastDoneHistoryEntryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLastDoneHistoryEntryStmt: %w", cerr)
+		}
+	}
+	if q.getPlaylistStmt != nil {
+		if cerr := q.getPlaylistStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPlaylistStmt: %w", cerr)
+		}
+	}
+	if q.getPlaylistVideoIDsByRankStmt != nil {
+		if cerr := q.getPlaylistVideoIDsByRankStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPlaylistVideoIDsByRankStmt: %w", cerr)
+		}
+	}
+	if q.getPlaylistVideoRankStmt != nil {
+		if cerr := q.getPlaylistVideoRankStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPlaylistVideoRankStmt: %w", cerr)
+		}
+	}
+	if q.getPlaylistVideosStmt != nil {
+		if cerr := q.getPlaylistVideosStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPlaylistVideosStmt: %w", cerr)
+		}
+	}
+	if q.getPlaylistsStmt != nil {
+		if cerr := q.getPlaylistsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPlaylistsStmt: %w", cerr)
+		}
+	}
+	if q.getSegmentsStmt != nil {
+		if cerr := q.getSegmentsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSegmentsStmt: %w", cerr)
+		}
+	}
+	if q.getSettingStmt != nil {
+		if cerr := q.getSettingStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSettingStmt: %w", cerr)
+		}
+	}
+	if q.getSubtitlesStmt != nil {
+		if cerr := q.getSubtitlesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSubtitlesStmt: %w", cerr)
+		}
+	}
+	if q.getTopPlaylistVideoIDStmt != nil {
+		if cerr := q.getTopPlaylistVideoIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTopPlaylistVideoIDStmt: %w", cerr)
+		}
+	}
+	if q.getTopVideoIDStmt != nil {
+		if cerr := q.getTopVideoIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTopVideoIDStmt: %w", cerr)
+		}
+	}
+	if q.getTrashedVideosStmt != nil {
+		if cerr := q.getTrashedVideosStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTrashedVideosStmt: %w", cerr)
+		}
+	}
+	if q.getUnindexedSubtitlesStmt != nil {
+		if cerr := q.getUnindexedSubtitlesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUnindexedSubtitlesStmt: %w", cerr)
+		}
+	}
+	if q.getVideoStmt != nil {
+		if cerr := q.getVideoStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getVideoStmt: %w", cerr)
+		}
+	}
+	if q.getVideoIDsByRankStmt != nil {
+		if cerr := q.getVideoIDsByRankStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getVideoIDsByRankStmt: %w", cerr)
+		}
+	}
+	if q.getVideoRankStmt != nil {
+		if cerr := q.getVideoRankStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getVideoRankStmt: %w", cerr)
+		}
+	}
+	if q.getVideoTagsStmt != nil {
+		if cerr := q.getVideoTagsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getVideoTagsStmt: %w", cerr)
+		}
+	}
 	if q.getVideosStmt != nil {
 		if cerr := q.getVideosStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getVideosStmt: %w", cerr)
 		}
 	}
+	if q.getVideosWithoutSizeStmt != nil {
+		if cerr := q.getVideosWithoutSizeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getVideosWithoutSizeStmt: %w", cerr)
+		}
+	}
+	if q.relinkVideoStmt != nil {
+		if cerr := q.relinkVideoStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing relinkVideoStmt: %w", cerr)
+		}
+	}
+	if q.removePlaylistVideoStmt != nil {
+		if cerr := q.removePlaylistVideoStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing removePlaylistVideoStmt: %w", cerr)
+		}
+	}
+	if q.restoreVideoStmt != nil {
+		if cerr := q.restoreVideoStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing restoreVideoStmt: %w", cerr)
+		}
+	}
+	if q.searchSubtitleCuesStmt != nil {
+		if cerr := q.searchSubtitleCuesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing searchSubtitleCuesStmt: %w", cerr)
+		}
+	}
+	if q.setChannelRuleStmt != nil {
+		if cerr := q.setChannelRuleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setChannelRuleStmt: %w", cerr)
+		}
+	}
+	if q.setHistoryEntryUndoneStmt != nil {
+		if cerr := q.setHistoryEntryUndoneStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setHistoryEntryUndoneStmt: %w", cerr)
+		}
+	}
+	if q.setSettingStmt != nil {
+		if cerr := q.setSettingStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setSettingStmt: %w", cerr)
+		}
+	}
+	if q.setVideoChannelStmt != nil {
+		if cerr := q.setVideoChannelStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setVideoChannelStmt: %w", cerr)
+		}
+	}
+	if q.setVideoKeepStmt != nil {
+		if cerr := q.setVideoKeepStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setVideoKeepStmt: %w", cerr)
+		}
+	}
+	if q.setVideoSizeStmt != nil {
+		if cerr := q.setVideoSizeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setVideoSizeStmt: %w", cerr)
+		}
+	}
+	if q.setVideoWatchedStmt != nil {
+		if cerr := q.setVideoWatchedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setVideoWatchedStmt: %w", cerr)
+		}
+	}
 	if q.setWatchedVideoStmt != nil {
 		if cerr := q.setWatchedVideoStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing setWatchedVideoStmt: %w", cerr)
 		}
 	}
+	if q.tagVideoStmt != nil {
+		if cerr := q.tagVideoStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing tagVideoStmt: %w", cerr)
+		}
+	}
+	if q.toggleVideoKeepStmt != nil {
+		if cerr := q.toggleVideoKeepStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing toggleVideoKeepStmt: %w", cerr)
+		}
+	}
 	if q.toggleWatchedStatusStmt != nil {
 		if cerr := q.toggleWatchedStatusStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing toggleWatchedStatusStmt: %w", cerr)
 		}
 	}
-	if q.updateVideoOrderStmt != nil {
-		if cerr := q.updateVideoOrderStmt.Close(); cerr != nil {
-			err = fmt.Errorf("error closing updateVideoOrderStmt: %w", cerr)
+	if q.trashVideoStmt != nil {
+		if cerr := q.trashVideoStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing trashVideoStmt: %w", cerr)
+		}
+	}
+	if q.trimHistoryStmt != nil {
+		if cerr := q.trimHistoryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing trimHistoryStmt: %w", cerr)
+		}
+	}
+	if q.untagVideoStmt != nil {
+		if cerr := q.untagVideoStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing untagVideoStmt: %w", cerr)
+		}
+	}
+	if q.updatePlaylistVideoRankStmt != nil {
+		if cerr := q.updatePlaylistVideoRankStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updatePlaylistVideoRankStmt: %w", cerr)
+		}
+	}
+	if q.updateVideoRankStmt != nil {
+		if cerr := q.updateVideoRankStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateVideoRankStmt: %w", cerr)
 		}
 	}
 	return err
@@ -114,25 +554,135 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 }
 
 type Queries struct {
-	db                      DBTX
-	tx                      *sql.Tx
-	addVideoStmt            *sql.Stmt
-	deleteVideoStmt         *sql.Stmt
-	getVideosStmt           *sql.Stmt
-	setWatchedVideoStmt     *sql.Stmt
-	toggleWatchedStatusStmt *sql.Stmt
-	updateVideoOrderStmt    *sql.Stmt
+	db                             DBTX
+	tx                             *sql.Tx
+	addBookmarkStmt                *sql.Stmt
+	addHistoryEntryStmt            *sql.Stmt
+	addPlaylistStmt                *sql.Stmt
+	addPlaylistVideoStmt           *sql.Stmt
+	addSegmentStmt                 *sql.Stmt
+	addSubtitleStmt                *sql.Stmt
+	addSubtitleCueStmt             *sql.Stmt
+	addTagStmt                     *sql.Stmt
+	addVideoStmt                   *sql.Stmt
+	clearResumePositionStmt        *sql.Stmt
+	deleteChannelRuleStmt          *sql.Stmt
+	deletePlaylistStmt             *sql.Stmt
+	deleteSegmentsStmt             *sql.Stmt
+	deleteSubtitleCuesStmt         *sql.Stmt
+	deleteSubtitlesStmt            *sql.Stmt
+	deleteTrashedVideoByURLStmt    *sql.Stmt
+	deleteUndoneHistoryStmt        *sql.Stmt
+	deleteUnusedTagsStmt           *sql.Stmt
+	deleteVideoStmt                *sql.Stmt
+	getBookmarksStmt               *sql.Stmt
+	getChannelRuleStmt             *sql.Stmt
+	getFirstUndoneHistoryEntryStmt *sql.Stmt
+	getLastDoneHistoryEntryStmt    *sql.Stmt
+	getPlaylistStmt                *sql.Stmt
+	getPlaylistVideoIDsByRankStmt  *sql.Stmt
+	getPlaylistVideoRankStmt       *sql.Stmt
+	getPlaylistVideosStmt          *sql.Stmt
+	getPlaylistsStmt               *sql.Stmt
+	getSegmentsStmt                *sql.Stmt
+	getSettingStmt                 *sql.Stmt
+	getSubtitlesStmt               *sql.Stmt
+	getTopPlaylistVideoIDStmt      *sql.Stmt
+	getTopVideoIDStmt              *sql.Stmt
+	getTrashedVideosStmt           *sql.Stmt
+	getUnindexedSubtitlesStmt      *sql.Stmt
+	getVideoStmt                   *sql.Stmt
+	getVideoIDsByRankStmt          *sql.Stmt
+	getVideoRankStmt               *sql.Stmt
+	getVideoTagsStmt               *sql.Stmt
+	getVideosStmt                  *sql.Stmt
+	getVideosWithoutSizeStmt       *sql.Stmt
+	relinkVideoStmt                *sql.Stmt
+	removePlaylistVideoStmt        *sql.Stmt
+	restoreVideoStmt               *sql.Stmt
+	searchSubtitleCuesStmt         *sql.Stmt
+	setChannelRuleStmt             *sql.Stmt
+	setHistoryEntryUndoneStmt      *sql.Stmt
+	setSettingStmt                 *sql.Stmt
+	setVideoChannelStmt            *sql.Stmt
+	setVideoKeepStmt               *sql.Stmt
+	setVideoSizeStmt               *sql.Stmt
+	setVideoWatchedStmt            *sql.Stmt
+	setWatchedVideoStmt            *sql.Stmt
+	tagVideoStmt                   *sql.Stmt
+	toggleVideoKeepStmt            *sql.Stmt
+	toggleWatchedStatusStmt        *sql.Stmt
+	trashVideoStmt                 *sql.Stmt
+	trimHistoryStmt                *sql.Stmt
+	untagVideoStmt                 *sql.Stmt
+	updatePlaylistVideoRankStmt    *sql.Stmt
+	updateVideoRankStmt            *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                      tx,
-		tx:                      tx,
-		addVideoStmt:            q.addVideoStmt,
-		deleteVideoStmt:         q.deleteVideoStmt,
-		getVideosStmt:           q.getVideosStmt,
-		setWatchedVideoStmt:     q.setWatchedVideoStmt,
-		toggleWatchedStatusStmt: q.toggleWatchedStatusStmt,
-		updateVideoOrderStmt:    q.updateVideoOrderStmt,
+		db:                             tx,
+		tx:                             tx,
+		addBookmarkStmt:                q.addBookmarkStmt,
+		addHistoryEntryStmt:            q.addHistoryEntryStmt,
+		addPlaylistStmt:                q.addPlaylistStmt,
+		addPlaylistVideoStmt:           q.addPlaylistVideoStmt,
+		addSegmentStmt:                 q.addSegmentStmt,
+		addSubtitleStmt:                q.addSubtitleStmt,
+		addSubtitleCueStmt:             q.addSubtitleCueStmt,
+		addTagStmt:                     q.addTagStmt,
+		addVideoStmt:                   q.addVideoStmt,
+		clearResumePositionStmt:        q.clearResumePositionStmt,
+		deleteChannelRuleStmt:          q.deleteChannelRuleStmt,
+		deletePlaylistStmt:             q.deletePlaylistStmt,
+		deleteSegmentsStmt:             q.deleteSegmentsStmt,
+		deleteSubtitleCuesStmt:         q.deleteSubtitleCuesStmt,
+		deleteSubtitlesStmt:            q.deleteSubtitlesStmt,
+		deleteTrashedVideoByURLStmt:    q.deleteTrashedVideoByURLStmt,
+		deleteUndoneHistoryStmt:        q.deleteUndoneHistoryStmt,
+		deleteUnusedTagsStmt:           q.deleteUnusedTagsStmt,
+		deleteVideoStmt:                q.deleteVideoStmt,
+		getBookmarksStmt:               q.getBookmarksStmt,
+		getChannelRuleStmt:             q.getChannelRuleStmt,
+		getFirstUndoneHistoryEntryStmt: q.getFirstUndoneHistoryEntryStmt,
+		getLastDoneHistoryEntryStmt:    q.getLastDoneHistoryEntryStmt,
+		getPlaylistStmt:                q.getPlaylistStmt,
+		getPlaylistVideoIDsByRankStmt:  q.getPlaylistVideoIDsByRankStmt,
+		getPlaylistVideoRankStmt:       q.getPlaylistVideoRankStmt,
+		getPlaylistVideosStmt:          q.getPlaylistVideosStmt,
+		getPlaylistsStmt:               q.getPlaylistsStmt,
+		getSegmentsStmt:                q.getSegmentsStmt,
+		getSettingStmt:                 q.getSettingStmt,
+		getSubtitlesStmt:               q.getSubtitlesStmt,
+		getTopPlaylistVideoIDStmt:      q.getTopPlaylistVideoIDStmt,
+		getTopVideoIDStmt:              q.getTopVideoIDStmt,
+		getTrashedVideosStmt:           q.getTrashedVideosStmt,
+		getUnindexedSubtitlesStmt:      q.getUnindexedSubtitlesStmt,
+		getVideoStmt:                   q.getVideoStmt,
+		getVideoIDsByRankStmt:          q.getVideoIDsByRankStmt,
+		getVideoRankStmt:               q.getVideoRankStmt,
+		getVideoTagsStmt:               q.getVideoTagsStmt,
+		getVideosStmt:                  q.getVideosStmt,
+		getVideosWithoutSizeStmt:       q.getVideosWithoutSizeStmt,
+		relinkVideoStmt:                q.relinkVideoStmt,
+		removePlaylistVideoStmt:        q.removePlaylistVideoStmt,
+		restoreVideoStmt:               q.restoreVideoStmt,
+		searchSubtitleCuesStmt:         q.searchSubtitleCuesStmt,
+		setChannelRuleStmt:             q.setChannelRuleStmt,
+		setHistoryEntryUndoneStmt:      q.setHistoryEntryUndoneStmt,
+		setSettingStmt:                 q.setSettingStmt,
+		setVideoChannelStmt:            q.setVideoChannelStmt,
+		setVideoKeepStmt:               q.setVideoKeepStmt,
+		setVideoSizeStmt:               q.setVideoSizeStmt,
+		setVideoWatchedStmt:            q.setVideoWatchedStmt,
+		setWatchedVideoStmt:            q.setWatchedVideoStmt,
+		tagVideoStmt:                   q.tagVideoStmt,
+		toggleVideoKeepStmt:            q.toggleVideoKeepStmt,
+		toggleWatchedStatusStmt:        q.toggleWatchedStatusStmt,
+		trashVideoStmt:                 q.trashVideoStmt,
+		trimHistoryStmt:                q.trimHistoryStmt,
+		untagVideoStmt:                 q.untagVideoStmt,
+		updatePlaylistVideoRankStmt:    q.updatePlaylistVideoRankStmt,
+		updateVideoRankStmt:            q.updateVideoRankStmt,
 	}
 }
@@ -8,12 +8,87 @@ import (
 	"time"
 )
 
+type Bookmark struct {
+	ID        int64      `json:"id"`
+	VideoID   int64      `json:"videoId"`
+	Position  float64    `json:"position"`
+	Note      string     `json:"note"`
+	CreatedAt *time.Time `json:"createdAt"`
+}
+
+type ChannelRule struct {
+	Channel     string  `json:"channel"`
+	StartOffset float64 `json:"startOffset"`
+	EndOffset   float64 `json:"endOffset"`
+	Speed       float64 `json:"speed"`
+}
+
+type History struct {
+	ID          int64      `json:"id"`
+	Description string     `json:"description"`
+	Operation   string     `json:"operation"`
+	Undone      bool       `json:"undone"`
+	CreatedAt   *time.Time `json:"createdAt"`
+}
+
+type Playlist struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	CreatedAt *time.Time `json:"createdAt"`
+}
+
+type PlaylistVideo struct {
+	PlaylistID int64  `json:"playlistId"`
+	VideoID    int64  `json:"videoId"`
+	Rank       string `json:"rank"`
+}
+
+type Segment struct {
+	ID        int64   `json:"id"`
+	VideoID   int64   `json:"videoId"`
+	Category  string  `json:"category"`
+	StartTime float64 `json:"startTime"`
+	EndTime   float64 `json:"endTime"`
+}
+
+type Setting struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type Subtitle struct {
+	ID       int64  `json:"id"`
+	VideoID  int64  `json:"videoId"`
+	Language string `json:"language"`
+	Path     string `json:"path"`
+}
+
+type Tag struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
 type Video struct {
-	ID         int64      `json:"id"`
-	Name       string     `json:"name"`
-	Url        string     `json:"url"`
-	Location   string     `json:"location"`
-	IsWatched  *bool      `json:"isWatched"`
-	OrderIndex *time.Time `json:"orderIndex"`
-	CreatedAt  *time.Time `json:"createdAt"`
+	ID             int64      `json:"id"`
+	Name           string     `json:"name"`
+	Url            string     `json:"url"`
+	Location       string     `json:"location"`
+	IsWatched      *bool      `json:"isWatched"`
+	Rank           string     `json:"rank"`
+	CreatedAt      *time.Time `json:"createdAt"`
+	Channel        *string    `json:"channel"`
+	ResumePosition *float64   `json:"resumePosition"`
+	SourceID       *int64     `json:"sourceId"`
+	UploadDate     *string    `json:"uploadDate"`
+	Duration       *float64   `json:"duration"`
+	DeletedAt      *time.Time `json:"deletedAt"`
+	TrashPath      *string    `json:"trashPath"`
+	Keep           bool       `json:"keep"`
+	WatchedAt      *time.Time `json:"watchedAt"`
+	Size           *int64     `json:"size"`
+}
+
+type VideoTag struct {
+	VideoID int64 `json:"videoId"`
+	TagID   int64 `json:"tagId"`
 }
@@ -10,18 +10,708 @@ import (
 	"time"
 )
 
+const addBookmark = `-- name: AddBookmark :exec
+INSERT INTO bookmarks (video_id, position, note) VALUES (?, ?, ?)
+`
+
+type AddBookmarkParams struct {
+	VideoID  int64   `json:"videoId"`
+	Position float64 `json:"position"`
+	Note     string  `json:"note"`
+}
+
+func (q *Queries) AddBookmark(ctx context.Context, arg AddBookmarkParams) error {
+	_, err := q.exec(ctx, q.addBookmarkStmt, addBookmark, arg.VideoID, arg.Position, arg.Note)
+	return err
+}
+
+const addHistoryEntry = `-- name: AddHistoryEntry :exec
+INSERT INTO history (description, operation) VALUES (?, ?)
+`
+
+type AddHistoryEntryParams struct {
+	Description string `json:"description"`
+	Operation   string `json:"operation"`
+}
+
+func (q *Queries) AddHistoryEntry(ctx context.Context, arg AddHistoryEntryParams) error {
+	_, err := q.exec(ctx, q.addHistoryEntryStmt, addHistoryEntry, arg.Description, arg.Operation)
+	return err
+}
+
+const addPlaylist = `-- name: AddPlaylist :one
+INSERT INTO playlists (name) VALUES (?) RETURNING id, name, created_at
+`
+
+func (q *Queries) AddPlaylist(ctx context.Context, name string) (Playlist, error) {
+	row := q.queryRow(ctx, q.addPlaylistStmt, addPlaylist, name)
+	var i Playlist
+	err := row.Scan(&i.ID, &i.Name, &i.CreatedAt)
+	return i, err
+}
+
+const addPlaylistVideo = `-- name: AddPlaylistVideo :exec
+INSERT INTO playlist_videos (playlist_id, video_id, rank) VALUES (?, ?, ?)
+ON CONFLICT(playlist_id, video_id) DO NOTHING
+`
+
+type AddPlaylistVideoParams struct {
+	PlaylistID int64  `json:"playlistId"`
+	VideoID    int64  `json:"videoId"`
+	Rank       string `json:"rank"`
+}
+
+func (q *Queries) AddPlaylistVideo(ctx context.Context, arg AddPlaylistVideoParams) error {
+	_, err := q.exec(ctx, q.addPlaylistVideoStmt, addPlaylistVideo, arg.PlaylistID, arg.VideoID, arg.Rank)
+	return err
+}
+
+const addSegment = `-- name: AddSegment :exec
+INSERT INTO segments (video_id, category, start_time, end_time) VALUES (?, ?, ?, ?)
+`
+
+type AddSegmentParams struct {
+	VideoID   int64   `json:"videoId"`
+	Category  string  `json:"category"`
+	StartTime float64 `json:"startTime"`
+	EndTime   float64 `json:"endTime"`
+}
+
+func (q *Queries) AddSegment(ctx context.Context, arg AddSegmentParams) error {
+	_, err := q.exec(ctx, q.addSegmentStmt, addSegment,
+		arg.VideoID,
+		arg.Category,
+		arg.StartTime,
+		arg.EndTime,
+	)
+	return err
+}
+
+const addSubtitle = `-- name: AddSubtitle :exec
+INSERT INTO subtitles (video_id, language, path) VALUES (?, ?, ?)
+`
+
+type AddSubtitleParams struct {
+	VideoID  int64  `json:"videoId"`
+	Language string `json:"language"`
+	Path     string `json:"path"`
+}
+
+func (q *Queries) AddSubtitle(ctx context.Context, arg AddSubtitleParams) error {
+	_, err := q.exec(ctx, q.addSubtitleStmt, addSubtitle, arg.VideoID, arg.Language, arg.Path)
+	return err
+}
+
+const addSubtitleCue = `-- name: AddSubtitleCue :exec
+INSERT INTO subtitle_cues (text, video_id, start_time) VALUES (?, ?, ?)
+`
+
+type AddSubtitleCueParams struct {
+	Text      string  `json:"text"`
+	VideoID   int64   `json:"videoId"`
+	StartTime float64 `json:"startTime"`
+}
+
+func (q *Queries) AddSubtitleCue(ctx context.Context, arg AddSubtitleCueParams) error {
+	_, err := q.exec(ctx, q.addSubtitleCueStmt, addSubtitleCue, arg.Text, arg.VideoID, arg.StartTime)
+	return err
+}
+
+const addTag = `-- name: AddTag :exec
+INSERT INTO tags (name) VALUES (?) ON CONFLICT(name) DO NOTHING
+`
+
+func (q *Queries) AddTag(ctx context.Context, name string) error {
+	_, err := q.exec(ctx, q.addTagStmt, addTag, name)
+	return err
+}
+
 const addVideo = `-- name: AddVideo :one
-INSERT INTO videos (name, url, location) values (?, ?, ?) RETURNING id, name, url, location, is_watched, order_index, created_at
+INSERT INTO videos (name, url, location, channel, resume_position, source_id, upload_date, duration, rank, size) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id, name, url, location, is_watched, rank, created_at, channel, resume_position, source_id, upload_date, duration, deleted_at, trash_path, keep, watched_at, size
 `
 
 type AddVideoParams struct {
-	Name     string `json:"name"`
-	Url      string `json:"url"`
+	Name           string   `json:"name"`
+	Url            string   `json:"url"`
+	Location       string   `json:"location"`
+	Channel        *string  `json:"channel"`
+	ResumePosition *float64 `json:"resumePosition"`
+	SourceID       *int64   `json:"sourceId"`
+	UploadDate     *string  `json:"uploadDate"`
+	Duration       *float64 `json:"duration"`
+	Rank           string   `json:"rank"`
+	Size           *int64   `json:"size"`
+}
+
+func (q *Queries) AddVideo(ctx context.Context, arg AddVideoParams) (Video, error) {
+	row := q.queryRow(ctx, q.addVideoStmt, addVideo,
+		arg.Name,
+		arg.Url,
+		arg.Location,
+		arg.Channel,
+		arg.ResumePosition,
+		arg.SourceID,
+		arg.UploadDate,
+		arg.Duration,
+		arg.Rank,
+		arg.Size,
+	)
+	var i Video
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Url,
+		&i.Location,
+		&i.IsWatched,
+		&i.Rank,
+		&i.CreatedAt,
+		&i.Channel,
+		&i.ResumePosition,
+		&i.SourceID,
+		&i.UploadDate,
+		&i.Duration,
+		&i.DeletedAt,
+		&i.TrashPath,
+		&i.Keep,
+		&i.WatchedAt,
+		&i.Size,
+	)
+	return i, err
+}
+
+const clearResumePosition = `-- name: ClearResumePosition :exec
+UPDATE videos SET resume_position = NULL WHERE id = ?
+`
+
+func (q *Queries) ClearResumePosition(ctx context.Context, id int64) error {
+	_, err := q.exec(ctx, q.clearResumePositionStmt, clearResumePosition, id)
+	return err
+}
+
+const deleteChannelRule = `-- name: DeleteChannelRule :exec
+DELETE FROM channel_rules WHERE channel = ?
+`
+
+func (q *Queries) DeleteChannelRule(ctx context.Context, channel string) error {
+	_, err := q.exec(ctx, q.deleteChannelRuleStmt, deleteChannelRule, channel)
+	return err
+}
+
+const deletePlaylist = `-- name: DeletePlaylist :exec
+DELETE FROM playlists WHERE id = ?
+`
+
+func (q *Queries) DeletePlaylist(ctx context.Context, id int64) error {
+	_, err := q.exec(ctx, q.deletePlaylistStmt, deletePlaylist, id)
+	return err
+}
+
+const deleteSegments = `-- name: DeleteSegments :exec
+DELETE FROM segments WHERE video_id = ?
+`
+
+func (q *Queries) DeleteSegments(ctx context.Context, videoID int64) error {
+	_, err := q.exec(ctx, q.deleteSegmentsStmt, deleteSegments, videoID)
+	return err
+}
+
+const deleteSubtitleCues = `-- name: DeleteSubtitleCues :exec
+DELETE FROM subtitle_cues WHERE video_id = ?
+`
+
+func (q *Queries) DeleteSubtitleCues(ctx context.Context, videoID int64) error {
+	_, err := q.exec(ctx, q.deleteSubtitleCuesStmt, deleteSubtitleCues, videoID)
+	return err
+}
+
+const deleteSubtitles = `-- name: DeleteSubtitles :exec
+DELETE FROM subtitles WHERE video_id = ?
+`
+
+func (q *Queries) DeleteSubtitles(ctx context.Context, videoID int64) error {
+	_, err := q.exec(ctx, q.deleteSubtitlesStmt, deleteSubtitles, videoID)
+	return err
+}
+
+const deleteTrashedVideoByURL = `-- name: DeleteTrashedVideoByURL :many
+DELETE FROM videos WHERE url = ? AND deleted_at IS NOT NULL RETURNING trash_path
+`
+
+func (q *Queries) DeleteTrashedVideoByURL(ctx context.Context, url string) ([]*string, error) {
+	rows, err := q.query(ctx, q.deleteTrashedVideoByURLStmt, deleteTrashedVideoByURL, url)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*string{}
+	for rows.Next() {
+		var trash_path *string
+		if err := rows.Scan(&trash_path); err != nil {
+			return nil, err
+		}
+		items = append(items, trash_path)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteUndoneHistory = `-- name: DeleteUndoneHistory :exec
+DELETE FROM history WHERE undone
+`
+
+func (q *Queries) DeleteUndoneHistory(ctx context.Context) error {
+	_, err := q.exec(ctx, q.deleteUndoneHistoryStmt, deleteUndoneHistory)
+	return err
+}
+
+const deleteUnusedTags = `-- name: DeleteUnusedTags :exec
+DELETE FROM tags WHERE id NOT IN (SELECT tag_id FROM video_tags)
+`
+
+func (q *Queries) DeleteUnusedTags(ctx context.Context) error {
+	_, err := q.exec(ctx, q.deleteUnusedTagsStmt, deleteUnusedTags)
+	return err
+}
+
+const deleteVideo = `-- name: DeleteVideo :exec
+DELETE FROM videos WHERE id = ?
+`
+
+func (q *Queries) DeleteVideo(ctx context.Context, id int64) error {
+	_, err := q.exec(ctx, q.deleteVideoStmt, deleteVideo, id)
+	return err
+}
+
+const getBookmarks = `-- name: GetBookmarks :many
+SELECT id, video_id, position, note, created_at FROM bookmarks WHERE video_id = ? ORDER BY position
+`
+
+func (q *Queries) GetBookmarks(ctx context.Context, videoID int64) ([]Bookmark, error) {
+	rows, err := q.query(ctx, q.getBookmarksStmt, getBookmarks, videoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Bookmark{}
+	for rows.Next() {
+		var i Bookmark
+		if err := rows.Scan(
+			&i.ID,
+			&i.VideoID,
+			&i.Position,
+			&i.Note,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getChannelRule = `-- name: GetChannelRule :one
+SELECT channel, start_offset, end_offset, speed FROM channel_rules WHERE channel = ?
+`
+
+func (q *Queries) GetChannelRule(ctx context.Context, channel string) (ChannelRule, error) {
+	row := q.queryRow(ctx, q.getChannelRuleStmt, getChannelRule, channel)
+	var i ChannelRule
+	err := row.Scan(
+		&i.Channel,
+		&i.StartOffset,
+		&i.EndOffset,
+		&i.Speed,
+	)
+	return i, err
+}
+
+const getFirstUndoneHistoryEntry = `-- name: GetFirstUndoneHistoryEntry :one
+SELECT id, description, operation, undone, created_at FROM history WHERE undone ORDER BY id LIMIT 1
+`
+
+func (q *Queries) GetFirstUndoneHistoryEntry(ctx context.Context) (History, error) {
+	row := q.queryRow(ctx, q.getFirstUndoneHistoryEntryStmt, getFirstUndoneHistoryEntry)
+	var i History
+	err := row.Scan(
+		&i.ID,
+		&i.Description,
+		&i.Operation,
+		&i.Undone,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getLastDoneHistoryEntry = `-- name: GetLastDoneHistoryEntry :one
+SELECT id, description, operation, undone, created_at FROM history WHERE NOT undone ORDER BY id DESC LIMIT 1
+`
+
+func (q *Queries) GetLastDoneHistoryEntry(ctx context.Context) (History, error) {
+	row := q.queryRow(ctx, q.getLastDoneHistoryEntryStmt, getLastDoneHistoryEntry)
+	var i History
+	err := row.Scan(
+		&i.ID,
+		&i.Description,
+		&i.Operation,
+		&i.Undone,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getPlaylist = `-- name: GetPlaylist :one
+SELECT id, name, created_at FROM playlists WHERE id = ?
+`
+
+func (q *Queries) GetPlaylist(ctx context.Context, id int64) (Playlist, error) {
+	row := q.queryRow(ctx, q.getPlaylistStmt, getPlaylist, id)
+	var i Playlist
+	err := row.Scan(&i.ID, &i.Name, &i.CreatedAt)
+	return i, err
+}
+
+const getPlaylists = `-- name: GetPlaylists :many
+SELECT id, name, created_at FROM playlists ORDER BY name
+`
+
+func (q *Queries) GetPlaylists(ctx context.Context) ([]Playlist, error) {
+	rows, err := q.query(ctx, q.getPlaylistsStmt, getPlaylists)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Playlist{}
+	for rows.Next() {
+		var i Playlist
+		if err := rows.Scan(&i.ID, &i.Name, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPlaylistVideoIDsByRank = `-- name: GetPlaylistVideoIDsByRank :many
+SELECT video_id FROM playlist_videos WHERE playlist_id = ? ORDER BY rank
+`
+
+func (q *Queries) GetPlaylistVideoIDsByRank(ctx context.Context, playlistID int64) ([]int64, error) {
+	rows, err := q.query(ctx, q.getPlaylistVideoIDsByRankStmt, getPlaylistVideoIDsByRank, playlistID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var video_id int64
+		if err := rows.Scan(&video_id); err != nil {
+			return nil, err
+		}
+		items = append(items, video_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPlaylistVideoRank = `-- name: GetPlaylistVideoRank :one
+SELECT rank FROM playlist_videos WHERE playlist_id = ? AND video_id = ?
+`
+
+type GetPlaylistVideoRankParams struct {
+	PlaylistID int64 `json:"playlistId"`
+	VideoID    int64 `json:"videoId"`
+}
+
+func (q *Queries) GetPlaylistVideoRank(ctx context.Context, arg GetPlaylistVideoRankParams) (string, error) {
+	row := q.queryRow(ctx, q.getPlaylistVideoRankStmt, getPlaylistVideoRank, arg.PlaylistID, arg.VideoID)
+	var rank string
+	err := row.Scan(&rank)
+	return rank, err
+}
+
+const getPlaylistVideos = `-- name: GetPlaylistVideos :many
+SELECT
+    videos.id,
+    videos.name,
+    videos.url,
+    videos.location,
+    videos.is_watched,
+    videos.rank,
+    videos.created_at,
+    videos.channel,
+    videos.resume_position,
+    videos.source_id,
+    videos.upload_date,
+    videos.duration,
+    videos.deleted_at,
+    videos.trash_path,
+    videos.keep,
+    videos.watched_at,
+    videos.size
+FROM videos
+JOIN playlist_videos ON playlist_videos.video_id = videos.id
+WHERE playlist_videos.playlist_id = ? AND videos.deleted_at IS NULL
+ORDER BY playlist_videos.rank DESC
+`
+
+func (q *Queries) GetPlaylistVideos(ctx context.Context, playlistID int64) ([]Video, error) {
+	rows, err := q.query(ctx, q.getPlaylistVideosStmt, getPlaylistVideos, playlistID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Video{}
+	for rows.Next() {
+		var i Video
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Url,
+			&i.Location,
+			&i.IsWatched,
+			&i.Rank,
+			&i.CreatedAt,
+			&i.Channel,
+			&i.ResumePosition,
+			&i.SourceID,
+			&i.UploadDate,
+			&i.Duration,
+			&i.DeletedAt,
+			&i.TrashPath,
+			&i.Keep,
+			&i.WatchedAt,
+			&i.Size,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSegments = `-- name: GetSegments :many
+SELECT id, video_id, category, start_time, end_time FROM segments WHERE video_id = ? ORDER BY start_time
+`
+
+func (q *Queries) GetSegments(ctx context.Context, videoID int64) ([]Segment, error) {
+	rows, err := q.query(ctx, q.getSegmentsStmt, getSegments, videoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Segment{}
+	for rows.Next() {
+		var i Segment
+		if err := rows.Scan(
+			&i.ID,
+			&i.VideoID,
+			&i.Category,
+			&i.StartTime,
+			&i.EndTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSetting = `-- name: GetSetting :one
+SELECT value FROM settings WHERE key = ?
+`
+
+func (q *Queries) GetSetting(ctx context.Context, key string) (string, error) {
+	row := q.queryRow(ctx, q.getSettingStmt, getSetting, key)
+	var value string
+	err := row.Scan(&value)
+	return value, err
+}
+
+const getSubtitles = `-- name: GetSubtitles :many
+SELECT id, video_id, language, path FROM subtitles WHERE video_id = ? ORDER BY language
+`
+
+func (q *Queries) GetSubtitles(ctx context.Context, videoID int64) ([]Subtitle, error) {
+	rows, err := q.query(ctx, q.getSubtitlesStmt, getSubtitles, videoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Subtitle{}
+	for rows.Next() {
+		var i Subtitle
+		if err := rows.Scan(
+			&i.ID,
+			&i.VideoID,
+			&i.Language,
+			&i.Path,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTopPlaylistVideoID = `-- name: GetTopPlaylistVideoID :one
+SELECT video_id FROM playlist_videos WHERE playlist_id = ? ORDER BY rank DESC LIMIT 1
+`
+
+func (q *Queries) GetTopPlaylistVideoID(ctx context.Context, playlistID int64) (int64, error) {
+	row := q.queryRow(ctx, q.getTopPlaylistVideoIDStmt, getTopPlaylistVideoID, playlistID)
+	var video_id int64
+	err := row.Scan(&video_id)
+	return video_id, err
+}
+
+const getTopVideoID = `-- name: GetTopVideoID :one
+SELECT id FROM videos ORDER BY rank DESC LIMIT 1
+`
+
+func (q *Queries) GetTopVideoID(ctx context.Context) (int64, error) {
+	row := q.queryRow(ctx, q.getTopVideoIDStmt, getTopVideoID)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getTrashedVideos = `-- name: GetTrashedVideos :many
+SELECT id, name, url, location, is_watched, rank, created_at, channel, resume_position, source_id, upload_date, duration, deleted_at, trash_path, keep, watched_at, size FROM videos WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC, id DESC
+`
+
+func (q *Queries) GetTrashedVideos(ctx context.Context) ([]Video, error) {
+	rows, err := q.query(ctx, q.getTrashedVideosStmt, getTrashedVideos)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Video{}
+	for rows.Next() {
+		var i Video
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Url,
+			&i.Location,
+			&i.IsWatched,
+			&i.Rank,
+			&i.CreatedAt,
+			&i.Channel,
+			&i.ResumePosition,
+			&i.SourceID,
+			&i.UploadDate,
+			&i.Duration,
+			&i.DeletedAt,
+			&i.TrashPath,
+			&i.Keep,
+			&i.WatchedAt,
+			&i.Size,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUnindexedSubtitles = `-- name: GetUnindexedSubtitles :many
+SELECT subtitles.video_id, subtitles.language, subtitles.path, videos.location, videos.name
+FROM subtitles
+JOIN videos ON videos.id = subtitles.video_id
+WHERE videos.deleted_at IS NULL
+    AND subtitles.video_id NOT IN (SELECT video_id FROM subtitle_cues)
+ORDER BY subtitles.video_id, subtitles.language
+`
+
+type GetUnindexedSubtitlesRow struct {
+	VideoID  int64  `json:"videoId"`
+	Language string `json:"language"`
+	Path     string `json:"path"`
 	Location string `json:"location"`
+	Name     string `json:"name"`
 }
 
-func (q *Queries) AddVideo(ctx context.Context, arg AddVideoParams) (Video, error) {
-	row := q.queryRow(ctx, q.addVideoStmt, addVideo, arg.Name, arg.Url, arg.Location)
+func (q *Queries) GetUnindexedSubtitles(ctx context.Context) ([]GetUnindexedSubtitlesRow, error) {
+	rows, err := q.query(ctx, q.getUnindexedSubtitlesStmt, getUnindexedSubtitles)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetUnindexedSubtitlesRow{}
+	for rows.Next() {
+		var i GetUnindexedSubtitlesRow
+		if err := rows.Scan(
+			&i.VideoID,
+			&i.Language,
+			&i.Path,
+			&i.Location,
+			&i.Name,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getVideo = `-- name: GetVideo :one
+SELECT id, name, url, location, is_watched, rank, created_at, channel, resume_position, source_id, upload_date, duration, deleted_at, trash_path, keep, watched_at, size FROM videos WHERE id = ?
+`
+
+func (q *Queries) GetVideo(ctx context.Context, id int64) (Video, error) {
+	row := q.queryRow(ctx, q.getVideoStmt, getVideo, id)
 	var i Video
 	err := row.Scan(
 		&i.ID,
@@ -29,23 +719,62 @@ func (q *Queries) AddVideo(ctx context.Context, arg AddVideoParams) (Video, erro
 		&i.Url,
 		&i.Location,
 		&i.IsWatched,
-		&i.OrderIndex,
+		&i.Rank,
 		&i.CreatedAt,
+		&i.Channel,
+		&i.ResumePosition,
+		&i.SourceID,
+		&i.UploadDate,
+		&i.Duration,
+		&i.DeletedAt,
+		&i.TrashPath,
+		&i.Keep,
+		&i.WatchedAt,
+		&i.Size,
 	)
 	return i, err
 }
 
-const deleteVideo = `-- name: DeleteVideo :exec
-DELETE FROM videos WHERE id = ?
+const getVideoIDsByRank = `-- name: GetVideoIDsByRank :many
+SELECT id FROM videos ORDER BY rank
 `
 
-func (q *Queries) DeleteVideo(ctx context.Context, id int64) error {
-	_, err := q.exec(ctx, q.deleteVideoStmt, deleteVideo, id)
-	return err
+func (q *Queries) GetVideoIDsByRank(ctx context.Context) ([]int64, error) {
+	rows, err := q.query(ctx, q.getVideoIDsByRankStmt, getVideoIDsByRank)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getVideoRank = `-- name: GetVideoRank :one
+SELECT rank FROM videos WHERE id = ?
+`
+
+func (q *Queries) GetVideoRank(ctx context.Context, id int64) (string, error) {
+	row := q.queryRow(ctx, q.getVideoRankStmt, getVideoRank, id)
+	var rank string
+	err := row.Scan(&rank)
+	return rank, err
 }
 
 const getVideos = `-- name: GetVideos :many
-SELECT id, name, url, location, is_watched, order_index, created_at FROM videos ORDER BY order_index DESC
+SELECT id, name, url, location, is_watched, rank, created_at, channel, resume_position, source_id, upload_date, duration, deleted_at, trash_path, keep, watched_at, size FROM videos WHERE deleted_at IS NULL ORDER BY rank DESC
 `
 
 func (q *Queries) GetVideos(ctx context.Context) ([]Video, error) {
@@ -63,8 +792,195 @@ func (q *Queries) GetVideos(ctx context.Context) ([]Video, error) {
 			&i.Url,
 			&i.Location,
 			&i.IsWatched,
-			&i.OrderIndex,
+			&i.Rank,
+			&i.CreatedAt,
+			&i.Channel,
+			&i.ResumePosition,
+			&i.SourceID,
+			&i.UploadDate,
+			&i.Duration,
+			&i.DeletedAt,
+			&i.TrashPath,
+			&i.Keep,
+			&i.WatchedAt,
+			&i.Size,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getVideosWithoutSize = `-- name: GetVideosWithoutSize :many
+SELECT id, name, url, location, is_watched, rank, created_at, channel, resume_position, source_id, upload_date, duration, deleted_at, trash_path, keep, watched_at, size FROM videos WHERE size IS NULL AND deleted_at IS NULL
+`
+
+func (q *Queries) GetVideosWithoutSize(ctx context.Context) ([]Video, error) {
+	rows, err := q.query(ctx, q.getVideosWithoutSizeStmt, getVideosWithoutSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Video{}
+	for rows.Next() {
+		var i Video
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Url,
+			&i.Location,
+			&i.IsWatched,
+			&i.Rank,
 			&i.CreatedAt,
+			&i.Channel,
+			&i.ResumePosition,
+			&i.SourceID,
+			&i.UploadDate,
+			&i.Duration,
+			&i.DeletedAt,
+			&i.TrashPath,
+			&i.Keep,
+			&i.WatchedAt,
+			&i.Size,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getVideoTags = `-- name: GetVideoTags :many
+SELECT video_tags.video_id, tags.name
+FROM video_tags
+JOIN tags ON tags.id = video_tags.tag_id
+ORDER BY tags.name
+`
+
+type GetVideoTagsRow struct {
+	VideoID int64  `json:"videoId"`
+	Name    string `json:"name"`
+}
+
+func (q *Queries) GetVideoTags(ctx context.Context) ([]GetVideoTagsRow, error) {
+	rows, err := q.query(ctx, q.getVideoTagsStmt, getVideoTags)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetVideoTagsRow
+	for rows.Next() {
+		var i GetVideoTagsRow
+		if err := rows.Scan(&i.VideoID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const relinkVideo = `-- name: RelinkVideo :exec
+UPDATE videos SET name = ?, location = ?, size = ? WHERE id = ?
+`
+
+type RelinkVideoParams struct {
+	Name     string `json:"name"`
+	Location string `json:"location"`
+	Size     *int64 `json:"size"`
+	ID       int64  `json:"id"`
+}
+
+func (q *Queries) RelinkVideo(ctx context.Context, arg RelinkVideoParams) error {
+	_, err := q.exec(ctx, q.relinkVideoStmt, relinkVideo,
+		arg.Name,
+		arg.Location,
+		arg.Size,
+		arg.ID,
+	)
+	return err
+}
+
+const removePlaylistVideo = `-- name: RemovePlaylistVideo :exec
+DELETE FROM playlist_videos WHERE playlist_id = ? AND video_id = ?
+`
+
+type RemovePlaylistVideoParams struct {
+	PlaylistID int64 `json:"playlistId"`
+	VideoID    int64 `json:"videoId"`
+}
+
+func (q *Queries) RemovePlaylistVideo(ctx context.Context, arg RemovePlaylistVideoParams) error {
+	_, err := q.exec(ctx, q.removePlaylistVideoStmt, removePlaylistVideo, arg.PlaylistID, arg.VideoID)
+	return err
+}
+
+const restoreVideo = `-- name: RestoreVideo :exec
+UPDATE videos SET deleted_at = NULL, trash_path = NULL WHERE id = ?
+`
+
+func (q *Queries) RestoreVideo(ctx context.Context, id int64) error {
+	_, err := q.exec(ctx, q.restoreVideoStmt, restoreVideo, id)
+	return err
+}
+
+const searchSubtitleCues = `-- name: SearchSubtitleCues :many
+SELECT
+    subtitle_cues.video_id,
+    subtitle_cues.start_time,
+    CAST(snippet(subtitle_cues, 0, '', '', '…', 12) AS TEXT) AS snippet,
+    videos.name
+FROM subtitle_cues
+JOIN videos ON videos.id = subtitle_cues.video_id
+WHERE subtitle_cues MATCH ? AND videos.deleted_at IS NULL
+ORDER BY subtitle_cues.rank
+LIMIT ?
+`
+
+type SearchSubtitleCuesParams struct {
+	Query string `json:"query"`
+	Limit int64  `json:"limit"`
+}
+
+type SearchSubtitleCuesRow struct {
+	VideoID   int64   `json:"videoId"`
+	StartTime float64 `json:"startTime"`
+	Snippet   string  `json:"snippet"`
+	Name      string  `json:"name"`
+}
+
+func (q *Queries) SearchSubtitleCues(ctx context.Context, arg SearchSubtitleCuesParams) ([]SearchSubtitleCuesRow, error) {
+	rows, err := q.query(ctx, q.searchSubtitleCuesStmt, searchSubtitleCues, arg.Query, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchSubtitleCuesRow{}
+	for rows.Next() {
+		var i SearchSubtitleCuesRow
+		if err := rows.Scan(
+			&i.VideoID,
+			&i.StartTime,
+			&i.Snippet,
+			&i.Name,
 		); err != nil {
 			return nil, err
 		}
@@ -79,8 +995,141 @@ func (q *Queries) GetVideos(ctx context.Context) ([]Video, error) {
 	return items, nil
 }
 
+const setChannelRule = `-- name: SetChannelRule :exec
+INSERT INTO channel_rules (channel, start_offset, end_offset, speed) VALUES (?, ?, ?, ?)
+ON CONFLICT(channel) DO UPDATE SET
+    start_offset = excluded.start_offset,
+    end_offset = excluded.end_offset,
+    speed = excluded.speed
+`
+
+type SetChannelRuleParams struct {
+	Channel     string  `json:"channel"`
+	StartOffset float64 `json:"startOffset"`
+	EndOffset   float64 `json:"endOffset"`
+	Speed       float64 `json:"speed"`
+}
+
+func (q *Queries) SetChannelRule(ctx context.Context, arg SetChannelRuleParams) error {
+	_, err := q.exec(ctx, q.setChannelRuleStmt, setChannelRule,
+		arg.Channel,
+		arg.StartOffset,
+		arg.EndOffset,
+		arg.Speed,
+	)
+	return err
+}
+
+const setHistoryEntryUndone = `-- name: SetHistoryEntryUndone :exec
+UPDATE history SET undone = ? WHERE id = ?
+`
+
+type SetHistoryEntryUndoneParams struct {
+	Undone bool  `json:"undone"`
+	ID     int64 `json:"id"`
+}
+
+func (q *Queries) SetHistoryEntryUndone(ctx context.Context, arg SetHistoryEntryUndoneParams) error {
+	_, err := q.exec(ctx, q.setHistoryEntryUndoneStmt, setHistoryEntryUndone, arg.Undone, arg.ID)
+	return err
+}
+
+const setSetting = `-- name: SetSetting :exec
+INSERT INTO settings (key, value) VALUES (?, ?)
+ON CONFLICT(key) DO UPDATE SET value = excluded.value
+`
+
+type SetSettingParams struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+func (q *Queries) SetSetting(ctx context.Context, arg SetSettingParams) error {
+	_, err := q.exec(ctx, q.setSettingStmt, setSetting, arg.Key, arg.Value)
+	return err
+}
+
+const setVideoChannel = `-- name: SetVideoChannel :one
+UPDATE videos SET channel = ? WHERE id = ? RETURNING id, name, url, location, is_watched, rank, created_at, channel, resume_position, source_id, upload_date, duration, deleted_at, trash_path, keep, watched_at, size
+`
+
+type SetVideoChannelParams struct {
+	Channel *string `json:"channel"`
+	ID      int64   `json:"id"`
+}
+
+func (q *Queries) SetVideoChannel(ctx context.Context, arg SetVideoChannelParams) (Video, error) {
+	row := q.queryRow(ctx, q.setVideoChannelStmt, setVideoChannel, arg.Channel, arg.ID)
+	var i Video
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Url,
+		&i.Location,
+		&i.IsWatched,
+		&i.Rank,
+		&i.CreatedAt,
+		&i.Channel,
+		&i.ResumePosition,
+		&i.SourceID,
+		&i.UploadDate,
+		&i.Duration,
+		&i.DeletedAt,
+		&i.TrashPath,
+		&i.Keep,
+		&i.WatchedAt,
+		&i.Size,
+	)
+	return i, err
+}
+
+const setVideoKeep = `-- name: SetVideoKeep :exec
+UPDATE videos SET keep = ? WHERE id = ?
+`
+
+type SetVideoKeepParams struct {
+	Keep bool  `json:"keep"`
+	ID   int64 `json:"id"`
+}
+
+func (q *Queries) SetVideoKeep(ctx context.Context, arg SetVideoKeepParams) error {
+	_, err := q.exec(ctx, q.setVideoKeepStmt, setVideoKeep, arg.Keep, arg.ID)
+	return err
+}
+
+const setVideoSize = `-- name: SetVideoSize :exec
+UPDATE videos SET size = ? WHERE id = ?
+`
+
+type SetVideoSizeParams struct {
+	Size *int64 `json:"size"`
+	ID   int64  `json:"id"`
+}
+
+func (q *Queries) SetVideoSize(ctx context.Context, arg SetVideoSizeParams) error {
+	_, err := q.exec(ctx, q.setVideoSizeStmt, setVideoSize, arg.Size, arg.ID)
+	return err
+}
+
+const setVideoWatched = `-- name: SetVideoWatched :exec
+UPDATE videos
+SET is_watched = ?1, watched_at = CASE WHEN ?1 THEN COALESCE(?2, CURRENT_TIMESTAMP) END
+WHERE id = ?3
+`
+
+type SetVideoWatchedParams struct {
+	IsWatched *bool      `json:"isWatched"`
+	WatchedAt *time.Time `json:"watchedAt"`
+	ID        int64      `json:"id"`
+}
+
+func (q *Queries) SetVideoWatched(ctx context.Context, arg SetVideoWatchedParams) error {
+	_, err := q.exec(ctx, q.setVideoWatchedStmt, setVideoWatched, arg.IsWatched, arg.WatchedAt, arg.ID)
+	return err
+}
+
 const setWatchedVideo = `-- name: SetWatchedVideo :one
-UPDATE videos SET is_watched = true WHERE id = ? RETURNING id, name, url, location, is_watched, order_index, created_at
+UPDATE videos SET is_watched = true, watched_at = CURRENT_TIMESTAMP WHERE id = ? RETURNING id, name, url, location, is_watched, rank, created_at, channel, resume_position, source_id, upload_date, duration, deleted_at, trash_path, keep, watched_at, size
 `
 
 func (q *Queries) SetWatchedVideo(ctx context.Context, id int64) (Video, error) {
@@ -92,14 +1141,69 @@ func (q *Queries) SetWatchedVideo(ctx context.Context, id int64) (Video, error)
 		&i.Url,
 		&i.Location,
 		&i.IsWatched,
-		&i.OrderIndex,
+		&i.Rank,
+		&i.CreatedAt,
+		&i.Channel,
+		&i.ResumePosition,
+		&i.SourceID,
+		&i.UploadDate,
+		&i.Duration,
+		&i.DeletedAt,
+		&i.TrashPath,
+		&i.Keep,
+		&i.WatchedAt,
+		&i.Size,
+	)
+	return i, err
+}
+
+const tagVideo = `-- name: TagVideo :exec
+INSERT INTO video_tags (video_id, tag_id)
+SELECT ?, id FROM tags WHERE name = ?
+ON CONFLICT DO NOTHING
+`
+
+type TagVideoParams struct {
+	VideoID int64  `json:"videoId"`
+	Name    string `json:"name"`
+}
+
+func (q *Queries) TagVideo(ctx context.Context, arg TagVideoParams) error {
+	_, err := q.exec(ctx, q.tagVideoStmt, tagVideo, arg.VideoID, arg.Name)
+	return err
+}
+
+const toggleVideoKeep = `-- name: ToggleVideoKeep :one
+UPDATE videos SET keep = not keep WHERE id = ? RETURNING id, name, url, location, is_watched, rank, created_at, channel, resume_position, source_id, upload_date, duration, deleted_at, trash_path, keep, watched_at, size
+`
+
+func (q *Queries) ToggleVideoKeep(ctx context.Context, id int64) (Video, error) {
+	row := q.queryRow(ctx, q.toggleVideoKeepStmt, toggleVideoKeep, id)
+	var i Video
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Url,
+		&i.Location,
+		&i.IsWatched,
+		&i.Rank,
 		&i.CreatedAt,
+		&i.Channel,
+		&i.ResumePosition,
+		&i.SourceID,
+		&i.UploadDate,
+		&i.Duration,
+		&i.DeletedAt,
+		&i.TrashPath,
+		&i.Keep,
+		&i.WatchedAt,
+		&i.Size,
 	)
 	return i, err
 }
 
 const toggleWatchedStatus = `-- name: ToggleWatchedStatus :one
-UPDATE videos SET is_watched = not is_watched WHERE id = ? RETURNING id, name, url, location, is_watched, order_index, created_at
+UPDATE videos SET is_watched = not is_watched, watched_at = CASE WHEN is_watched THEN NULL ELSE CURRENT_TIMESTAMP END WHERE id = ? RETURNING id, name, url, location, is_watched, rank, created_at, channel, resume_position, source_id, upload_date, duration, deleted_at, trash_path, keep, watched_at, size
 `
 
 func (q *Queries) ToggleWatchedStatus(ctx context.Context, id int64) (Video, error) {
@@ -111,22 +1215,85 @@ func (q *Queries) ToggleWatchedStatus(ctx context.Context, id int64) (Video, err
 		&i.Url,
 		&i.Location,
 		&i.IsWatched,
-		&i.OrderIndex,
+		&i.Rank,
 		&i.CreatedAt,
+		&i.Channel,
+		&i.ResumePosition,
+		&i.SourceID,
+		&i.UploadDate,
+		&i.Duration,
+		&i.DeletedAt,
+		&i.TrashPath,
+		&i.Keep,
+		&i.WatchedAt,
+		&i.Size,
 	)
 	return i, err
 }
 
-const updateVideoOrder = `-- name: UpdateVideoOrder :exec
-UPDATE videos SET order_index = ? WHERE id = ?
+const trashVideo = `-- name: TrashVideo :exec
+UPDATE videos SET deleted_at = CURRENT_TIMESTAMP, trash_path = ? WHERE id = ?
+`
+
+type TrashVideoParams struct {
+	TrashPath *string `json:"trashPath"`
+	ID        int64   `json:"id"`
+}
+
+func (q *Queries) TrashVideo(ctx context.Context, arg TrashVideoParams) error {
+	_, err := q.exec(ctx, q.trashVideoStmt, trashVideo, arg.TrashPath, arg.ID)
+	return err
+}
+
+const trimHistory = `-- name: TrimHistory :exec
+DELETE FROM history WHERE id NOT IN (SELECT id FROM history ORDER BY id DESC LIMIT ?)
+`
+
+func (q *Queries) TrimHistory(ctx context.Context, limit int64) error {
+	_, err := q.exec(ctx, q.trimHistoryStmt, trimHistory, limit)
+	return err
+}
+
+const untagVideo = `-- name: UntagVideo :exec
+DELETE FROM video_tags
+WHERE video_id = ? AND tag_id IN (SELECT id FROM tags WHERE name = ?)
+`
+
+type UntagVideoParams struct {
+	VideoID int64  `json:"videoId"`
+	Name    string `json:"name"`
+}
+
+func (q *Queries) UntagVideo(ctx context.Context, arg UntagVideoParams) error {
+	_, err := q.exec(ctx, q.untagVideoStmt, untagVideo, arg.VideoID, arg.Name)
+	return err
+}
+
+const updatePlaylistVideoRank = `-- name: UpdatePlaylistVideoRank :exec
+UPDATE playlist_videos SET rank = ? WHERE playlist_id = ? AND video_id = ?
+`
+
+type UpdatePlaylistVideoRankParams struct {
+	Rank       string `json:"rank"`
+	PlaylistID int64  `json:"playlistId"`
+	VideoID    int64  `json:"videoId"`
+}
+
+func (q *Queries) UpdatePlaylistVideoRank(ctx context.Context, arg UpdatePlaylistVideoRankParams) error {
+	_, err := q.exec(ctx, q.updatePlaylistVideoRankStmt, updatePlaylistVideoRank, arg.Rank, arg.PlaylistID, arg.VideoID)
+	return err
+}
+
+const updateVideoRank = `-- name: UpdateVideoRank :exec
+UPDATE videos SET rank = ? WHERE id = ?
 `
 
-type UpdateVideoOrderParams struct {
-	OrderIndex *time.Time `json:"orderIndex"`
-	ID         int64      `json:"id"`
+type UpdateVideoRankParams struct {
+	Rank string `json:"rank"`
+	ID   int64  `json:"id"`
 }
 
-func (q *Queries) UpdateVideoOrder(ctx context.Context, arg UpdateVideoOrderParams) error {
-	_, err := q.exec(ctx, q.updateVideoOrderStmt, updateVideoOrder, arg.OrderIndex, arg.ID)
+func (q *Queries) UpdateVideoRank(ctx context.Context, arg UpdateVideoRankParams) error {
+	_, err := q.exec(ctx, q.updateVideoRankStmt, updateVideoRank, arg.Rank, arg.ID)
 	return err
 }
@@ -2,9 +2,15 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/linnovs/ytqueue/database"
@@ -32,31 +38,166 @@ func videoToRow(v database.Video) row {
 		colURL:      v.Url,
 		colLocation: v.Location,
 		colWatched:  boolToYesNo(*v.IsWatched),
-		colOrder:    fmt.Sprint(v.OrderIndex.Unix()),
+		colChannel:  derefOr(v.Channel, ""),
+		colResume:   formatSeconds(v.ResumePosition),
+		colAdded:    formatCreatedAt(v.CreatedAt),
+		colUploaded: formatUploadDate(derefOr(v.UploadDate, "")),
+		colDuration: formatSeconds(v.Duration),
+		colSize:     formatSize(v.Size),
+		colKeep:     boolToYesNo(v.Keep),
 	}
 }
 
-func videosToRows(videos []database.Video) []row {
+func formatCreatedAt(createdAt *time.Time) string {
+	if createdAt == nil {
+		return ""
+	}
+
+	return createdAt.Local().Format(time.DateTime)
+}
+
+// formatUploadDate turns the YYYYMMDD upload date of yt-dlp into a date.
+func formatUploadDate(date string) string {
+	t, err := time.Parse("20060102", date)
+	if err != nil {
+		return ""
+	}
+
+	return t.Format(time.DateOnly)
+}
+
+// fileSize is the size in bytes of the file at path, stored with the video
+// when its file is added or replaced. It is nil when the file is missing.
+func fileSize(path string) *int64 {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil
+	}
+
+	size := info.Size()
+
+	return &size
+}
+
+func formatSize(size *int64) string {
+	if size == nil {
+		return ""
+	}
+
+	return strconv.FormatInt(*size, 10)
+}
+
+func videosToRows(videos []database.Video, tags map[int64][]string) []row {
 	rows := make([]row, 0, len(videos))
 
 	for _, v := range videos {
-		rows = append(rows, videoToRow(v))
+		r := videoToRow(v)
+		r[colTags] = strings.Join(tags[v.ID], " ")
+		rows = append(rows, r)
 	}
 
 	return rows
 }
 
+// updatedRow is the row of video replacing old, keeping the columns that are
+// not stored with the video.
+func updatedRow(old row, video database.Video) row {
+	r := videoToRow(video)
+	r[colTags] = old[colTags]
+
+	return r
+}
+
+func derefOr[T any](p *T, fallback T) T {
+	if p == nil {
+		return fallback
+	}
+
+	return *p
+}
+
+// formatSeconds formats an optional number of seconds, such as the resume
+// position, for a row.
+func formatSeconds(seconds *float64) string {
+	if seconds == nil {
+		return ""
+	}
+
+	return strconv.FormatFloat(*seconds, 'f', -1, 64)
+}
+
+func parseSeconds(s string) time.Duration {
+	seconds, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+
+	return time.Duration(seconds * float64(time.Second))
+}
+
 func idStrToInt(idStr string) (int64, error) {
 	return strconv.ParseInt(idStr, 10, 0)
 }
 
-type datastore struct{ queries *database.Queries }
+func idStrsToInts(idStrs []string) ([]int64, error) {
+	ids := make([]int64, 0, len(idStrs))
+
+	for _, idStr := range idStrs {
+		id, err := idStrToInt(idStr)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// optionalIDStrToInt is idStrToInt with an empty id being zero.
+func optionalIDStrToInt(idStr string) (int64, error) {
+	if idStr == "" {
+		return 0, nil
+	}
+
+	return idStrToInt(idStr)
+}
+
+type datastore struct {
+	db      *sql.DB
+	queries *database.Queries
+	// historyDepth is how many changes are kept to undo, none are recorded
+	// when it is zero.
+	historyDepth int
+}
+
+func newDatastore(db *sql.DB, queries *database.Queries) *datastore {
+	return &datastore{db: db, queries: queries}
+}
+
+// inTx runs fn with the queries bound to a transaction, it is committed when
+// fn succeeds and rolled back otherwise.
+func (s *datastore) inTx(ctx context.Context, fn func(q *database.Queries) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint:errcheck
+
+	if err := fn(s.queries.WithTx(tx)); err != nil {
+		return err
+	}
 
-func newDatastore(queries *database.Queries) *datastore {
-	return &datastore{queries: queries}
+	return tx.Commit()
 }
 
-func (s *datastore) getVideos(ctx context.Context) ([]database.Video, error) {
+// getVideos returns the videos of the playlist in its order, all videos when
+// playlistID is zero.
+func (s *datastore) getVideos(ctx context.Context, playlistID int64) ([]database.Video, error) {
+	if playlistID != 0 {
+		return s.queries.GetPlaylistVideos(ctx, playlistID)
+	}
+
 	videos, err := s.queries.GetVideos(ctx)
 	if err != nil {
 		return nil, err
@@ -65,83 +206,1273 @@ func (s *datastore) getVideos(ctx context.Context) ([]database.Video, error) {
 	return videos, nil
 }
 
-func (s *datastore) addVideo(
-	ctx context.Context,
-	name, url, location string,
-) (*database.Video, error) {
-	video, err := s.queries.AddVideo(ctx, database.AddVideoParams{
-		Name:     name,
-		Url:      url,
-		Location: location,
+type newVideo struct {
+	name, url, location, channel string
+	uploadDate                   string // YYYYMMDD
+	duration                     float64
+	start                        time.Duration
+	sourceID                     int64 // video a clip was cut from
+	playlistID                   int64 // playlist the video is also added to
+}
+
+func (s *datastore) getVideo(ctx context.Context, idStr string) (*database.Video, error) {
+	id, err := idStrToInt(idStr)
+	if err != nil {
+		return nil, err
+	}
+
+	video, err := s.queries.GetVideo(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &video, nil
+}
+
+func (s *datastore) addVideo(ctx context.Context, v newVideo) (*database.Video, error) {
+	params := database.AddVideoParams{
+		Name:     v.name,
+		Url:      v.url,
+		Location: v.location,
+	}
+
+	if v.channel != "" {
+		params.Channel = &v.channel
+	}
+
+	if v.uploadDate != "" {
+		params.UploadDate = &v.uploadDate
+	}
+
+	if v.duration > 0 {
+		params.Duration = &v.duration
+	}
+
+	if v.start > 0 {
+		start := v.start.Seconds()
+		params.ResumePosition = &start
+	}
+
+	if v.sourceID != 0 {
+		params.SourceID = &v.sourceID
+	}
+
+	params.Size = fileSize(filepath.Join(v.location, v.name))
+
+	var (
+		video      database.Video
+		trashPaths []*string
+	)
+
+	err := s.inTx(ctx, func(q *database.Queries) error {
+		// downloading a trashed video again replaces the trashed one, its file
+		// is deleted from the trash once the new video is added
+		var err error
+		if trashPaths, err = q.DeleteTrashedVideoByURL(ctx, v.url); err != nil {
+			return err
+		}
+
+		order := newVideoOrder(q, 0)
+
+		top, err := order.topID(ctx)
+		if err != nil {
+			return err
+		}
+
+		if params.Rank, err = videoRankBetween(ctx, order, top, 0); err != nil {
+			return err
+		}
+
+		if video, err = q.AddVideo(ctx, params); err != nil {
+			return err
+		}
+
+		if v.playlistID == 0 {
+			return nil
+		}
+
+		return addPlaylistVideo(ctx, q, v.playlistID, video.ID)
 	})
 	if err != nil {
 		var sqliteErr *sqlite.Error
 
 		if ok := errors.As(err, &sqliteErr); ok {
 			if sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
-				return nil, fmt.Errorf("video with URL %q already exists", url)
+				return nil, fmt.Errorf(
+					"video named %q or with URL %q already exists, maybe in the trash",
+					v.name,
+					v.url,
+				)
 			}
 		}
 
 		return nil, err
 	}
 
+	for _, trashPath := range trashPaths {
+		if trashPath == nil {
+			continue
+		}
+
+		if err := purgeFromTrash(*trashPath); err != nil {
+			slog.Warn(
+				"unable to delete replaced video from trash",
+				slog.String("path", *trashPath),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
 	return &video, nil
 }
 
-func (s *datastore) updateVideoOrder(ctx context.Context, idStr string, orderUnix int64) error {
-	id, err := idStrToInt(idStr)
+func (s *datastore) addSegments(
+	ctx context.Context,
+	videoID int64,
+	segments []sponsorSegment,
+) error {
+	return addSegments(ctx, s.queries, videoID, segments)
+}
+
+func addSegments(
+	ctx context.Context,
+	q *database.Queries,
+	videoID int64,
+	segments []sponsorSegment,
+) error {
+	for _, segment := range segments {
+		if err := q.AddSegment(ctx, database.AddSegmentParams{
+			VideoID:   videoID,
+			Category:  segment.Category,
+			StartTime: segment.StartTime,
+			EndTime:   segment.EndTime,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getVideoTags returns the tags of every video by video id.
+func (s *datastore) getVideoTags(ctx context.Context) (map[int64][]string, error) {
+	return videoTagsByID(ctx, s.queries)
+}
+
+func videoTagsByID(ctx context.Context, q *database.Queries) (map[int64][]string, error) {
+	videoTags, err := q.GetVideoTags(ctx)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	tags := make(map[int64][]string)
+	for _, t := range videoTags {
+		tags[t.VideoID] = append(tags[t.VideoID], t.Name)
 	}
 
-	idx := time.Unix(orderUnix, 0).UTC()
+	return tags, nil
+}
 
-	if err := s.queries.UpdateVideoOrder(ctx, database.UpdateVideoOrderParams{
-		ID:         id,
-		OrderIndex: &idx,
-	}); err != nil {
+// tagVideos adds and removes tags of the videos, tags no video has anymore are
+// deleted.
+func (s *datastore) tagVideos(ctx context.Context, idStrs []string, add, remove []string) error {
+	ids, err := idStrsToInts(idStrs)
+	if err != nil {
 		return err
 	}
 
+	return s.inTx(ctx, func(q *database.Queries) error {
+		tags, err := videoTagsByID(ctx, q)
+		if err != nil {
+			return err
+		}
+
+		op := operation{Kind: opTags}
+
+		for _, id := range ids {
+			before := tags[id]
+
+			after := strings.Fields(applyTagChanges(strings.Join(before, " "), add, remove))
+			if slices.Equal(before, after) {
+				continue
+			}
+
+			if err := changeVideoTags(ctx, q, id, before, after); err != nil {
+				return err
+			}
+
+			op.Videos = append(op.Videos, opVideo{
+				ID:     id,
+				Before: opState{Tags: before},
+				After:  opState{Tags: after},
+			})
+		}
+
+		if err := q.DeleteUnusedTags(ctx); err != nil {
+			return err
+		}
+
+		return s.record(ctx, q, "change tags of "+describeVideos(ctx, q, op.videoIDs()), op)
+	})
+}
+
+// changeVideoTags changes the tags of the video from one set to the other.
+func changeVideoTags(ctx context.Context, q *database.Queries, id int64, from, to []string) error {
+	for _, tag := range to {
+		if slices.Contains(from, tag) {
+			continue
+		}
+
+		if err := q.AddTag(ctx, tag); err != nil {
+			return err
+		}
+
+		if err := q.TagVideo(ctx, database.TagVideoParams{VideoID: id, Name: tag}); err != nil {
+			return err
+		}
+	}
+
+	for _, tag := range from {
+		if slices.Contains(to, tag) {
+			continue
+		}
+
+		if err := q.UntagVideo(ctx, database.UntagVideoParams{VideoID: id, Name: tag}); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-func (s *datastore) setWatched(ctx context.Context, idStr string) (*database.Video, error) {
-	id, err := idStrToInt(idStr)
+func (s *datastore) getPlaylists(ctx context.Context) ([]database.Playlist, error) {
+	return s.queries.GetPlaylists(ctx)
+}
+
+// getPlaylist returns the playlist with id, nil is returned when it does not
+// exist anymore.
+func (s *datastore) getPlaylist(ctx context.Context, id int64) (*database.Playlist, error) {
+	playlist, err := s.queries.GetPlaylist(ctx, id)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
 		return nil, err
 	}
 
-	video, err := s.queries.SetWatchedVideo(ctx, id)
+	return &playlist, nil
+}
+
+func (s *datastore) addPlaylist(ctx context.Context, name string) (*database.Playlist, error) {
+	playlist, err := s.queries.AddPlaylist(ctx, name)
 	if err != nil {
+		var sqliteErr *sqlite.Error
+
+		if errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+			return nil, fmt.Errorf("playlist %q already exists", name)
+		}
+
 		return nil, err
 	}
 
-	return &video, nil
+	return &playlist, nil
 }
 
-func (s *datastore) toggleWatched(ctx context.Context, idStr string) (*database.Video, error) {
-	id, err := idStrToInt(idStr)
+// deletePlaylist deletes the playlist, its videos stay in the library.
+func (s *datastore) deletePlaylist(ctx context.Context, id int64) error {
+	return s.queries.DeletePlaylist(ctx, id)
+}
+
+// addPlaylistVideo puts the video on top of the playlist, a video already in
+// the playlist keeps its position.
+func addPlaylistVideo(ctx context.Context, q *database.Queries, playlistID, videoID int64) error {
+	order := newVideoOrder(q, playlistID)
+
+	top, err := order.topID(ctx)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	video, err := s.queries.ToggleWatchedStatus(ctx, id)
+	rank, err := videoRankBetween(ctx, order, top, 0)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return &video, nil
+	return q.AddPlaylistVideo(ctx, database.AddPlaylistVideoParams{
+		PlaylistID: playlistID,
+		VideoID:    videoID,
+		Rank:       rank,
+	})
 }
 
-func (s *datastore) deleteVideo(ctx context.Context, idStr string) error {
-	id, err := idStrToInt(idStr)
+// addToPlaylist puts the videos on top of the playlist keeping the order they
+// are given in.
+func (s *datastore) addToPlaylist(ctx context.Context, playlistID int64, idStrs []string) error {
+	ids, err := idStrsToInts(idStrs)
 	if err != nil {
 		return err
 	}
 
-	return s.queries.DeleteVideo(ctx, id)
+	return s.inTx(ctx, func(q *database.Queries) error {
+		op := operation{Kind: opPlaylistAdd, PlaylistID: playlistID}
+
+		for _, id := range slices.Backward(ids) {
+			_, err := q.GetPlaylistVideoRank(ctx, database.GetPlaylistVideoRankParams{
+				PlaylistID: playlistID,
+				VideoID:    id,
+			})
+			if err == nil {
+				continue
+			}
+
+			if !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+
+			if err := addPlaylistVideo(ctx, q, playlistID, id); err != nil {
+				return err
+			}
+
+			op.Videos = append(op.Videos, opVideo{ID: id})
+		}
+
+		playlist, err := q.GetPlaylist(ctx, playlistID)
+		if err != nil {
+			return err
+		}
+
+		description := fmt.Sprintf(
+			"add %s to %s",
+			describeVideos(ctx, q, op.videoIDs()),
+			playlist.Name,
+		)
+
+		return s.record(ctx, q, description, op)
+	})
+}
+
+// removeFromPlaylist takes the videos out of the playlist, they stay in the
+// library.
+func (s *datastore) removeFromPlaylist(
+	ctx context.Context,
+	playlistID int64,
+	idStrs []string,
+) error {
+	ids, err := idStrsToInts(idStrs)
+	if err != nil {
+		return err
+	}
+
+	return s.inTx(ctx, func(q *database.Queries) error {
+		order, err := newVideoOrder(q, playlistID).idsByRank(ctx)
+		if err != nil {
+			return err
+		}
+
+		op := operation{Kind: opPlaylistRemove, PlaylistID: playlistID}
+
+		for _, id := range ids {
+			// the neighbours put the video back where it was on undo
+			op.Videos = append(op.Videos, opVideo{ID: id, Before: neighbours(order, id)})
+			order = slices.DeleteFunc(order, func(other int64) bool { return other == id })
+
+			if err := q.RemovePlaylistVideo(ctx, database.RemovePlaylistVideoParams{
+				PlaylistID: playlistID,
+				VideoID:    id,
+			}); err != nil {
+				return err
+			}
+		}
+
+		playlist, err := q.GetPlaylist(ctx, playlistID)
+		if err != nil {
+			return err
+		}
+
+		description := fmt.Sprintf(
+			"remove %s from %s",
+			describeVideos(ctx, q, op.videoIDs()),
+			playlist.Name,
+		)
+
+		return s.record(ctx, q, description, op)
+	})
+}
+
+func (s *datastore) addSubtitles(
+	ctx context.Context,
+	videoID int64,
+	subtitles []downloadedSubtitle,
+) error {
+	return addSubtitles(ctx, s.queries, videoID, subtitles)
+}
+
+func addSubtitles(
+	ctx context.Context,
+	q *database.Queries,
+	videoID int64,
+	subtitles []downloadedSubtitle,
+) error {
+	for _, subtitle := range subtitles {
+		if err := q.AddSubtitle(ctx, database.AddSubtitleParams{
+			VideoID:  videoID,
+			Language: subtitle.language,
+			Path:     subtitle.path,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// indexSubtitles adds the cues of the subtitles to the full-text index.
+func (s *datastore) indexSubtitles(
+	ctx context.Context,
+	videoID int64,
+	videoPath string,
+	subtitles []downloadedSubtitle,
+) error {
+	return addSubtitleCues(ctx, s.queries, videoID, readSubtitleCues(ctx, videoPath, subtitles))
+}
+
+// readSubtitleCues reads the cues of the subtitles, the embedded ones are read
+// from the subtitle streams of the video file in order. Subtitles that cannot
+// be read are logged and skipped.
+func readSubtitleCues(
+	ctx context.Context,
+	videoPath string,
+	subtitles []downloadedSubtitle,
+) []subtitleCue {
+	var all []subtitleCue
+
+	embedded := 0
+
+	for _, subtitle := range subtitles {
+		var (
+			cues []subtitleCue
+			err  error
+		)
+
+		if subtitle.path == "" {
+			cues, err = parseEmbeddedSubtitle(ctx, videoPath, embedded)
+			embedded++
+		} else {
+			cues, err = parseSubtitleFile(subtitle.path)
+		}
+
+		if err != nil {
+			slog.Warn(
+				"unable to index subtitle",
+				slog.String("path", subtitle.path),
+				slog.String("video", videoPath),
+				slog.String("language", subtitle.language),
+				slog.String("error", err.Error()),
+			)
+
+			continue
+		}
+
+		all = append(all, cues...)
+	}
+
+	return all
+}
+
+func addSubtitleCues(
+	ctx context.Context,
+	q *database.Queries,
+	videoID int64,
+	cues []subtitleCue,
+) error {
+	for _, cue := range cues {
+		if err := q.AddSubtitleCue(ctx, database.AddSubtitleCueParams{
+			Text:      cue.text,
+			VideoID:   videoID,
+			StartTime: cue.start.Seconds(),
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// indexStoredSubtitles indexes the subtitles of the videos without any cue in
+// the index and returns how many videos got indexed.
+func (s *datastore) indexStoredSubtitles(ctx context.Context) (int, error) {
+	rows, err := s.queries.GetUnindexedSubtitles(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	videos := 0
+
+	for start := 0; start < len(rows); {
+		end := start
+		for end < len(rows) && rows[end].VideoID == rows[start].VideoID {
+			end++
+		}
+
+		subtitles := make([]downloadedSubtitle, 0, end-start)
+		for _, row := range rows[start:end] {
+			subtitles = append(subtitles, downloadedSubtitle{language: row.Language, path: row.Path})
+		}
+
+		videoPath := filepath.Join(rows[start].Location, rows[start].Name)
+		if err := s.indexSubtitles(ctx, rows[start].VideoID, videoPath, subtitles); err != nil {
+			return videos, err
+		}
+
+		videos++
+		start = end
+	}
+
+	return videos, nil
+}
+
+// searchSubtitles finds the cues matching every word of query.
+func (s *datastore) searchSubtitles(
+	ctx context.Context,
+	query string,
+	limit int,
+) ([]database.SearchSubtitleCuesRow, error) {
+	terms := strings.Fields(query)
+	for i, term := range terms {
+		terms[i] = `"` + strings.ReplaceAll(term, `"`, `""`) + `"`
+	}
+
+	return s.queries.SearchSubtitleCues(ctx, database.SearchSubtitleCuesParams{
+		Query: strings.Join(terms, " "),
+		Limit: int64(limit),
+	})
+}
+
+func (s *datastore) getSegments(ctx context.Context, idStr string) ([]database.Segment, error) {
+	id, err := idStrToInt(idStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.queries.GetSegments(ctx, id)
+}
+
+// getChannelRule returns the playback rule of channel, nil is returned when
+// the channel has none.
+func (s *datastore) getChannelRule(
+	ctx context.Context,
+	channel string,
+) (*database.ChannelRule, error) {
+	if channel == "" {
+		return nil, nil
+	}
+
+	rule, err := s.queries.GetChannelRule(ctx, channel)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &rule, nil
+}
+
+func (s *datastore) setChannelRule(ctx context.Context, rule database.ChannelRule) error {
+	return s.changeChannelRule(ctx, rule.Channel, &rule, "change playback rule of "+rule.Channel)
+}
+
+func (s *datastore) deleteChannelRule(ctx context.Context, channel string) error {
+	return s.changeChannelRule(ctx, channel, nil, "remove playback rule of "+channel)
+}
+
+// changeChannelRule sets the playback rule of the channel, or removes it when
+// rule is nil.
+func (s *datastore) changeChannelRule(
+	ctx context.Context,
+	channel string,
+	rule *database.ChannelRule,
+	description string,
+) error {
+	return s.inTx(ctx, func(q *database.Queries) error {
+		before, err := q.GetChannelRule(ctx, channel)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		op := operation{Kind: opChannelRule, Rule: &opRule{Channel: channel, After: rule}}
+		if err == nil {
+			op.Rule.Before = &before
+		}
+
+		if err := writeChannelRule(ctx, q, channel, rule); err != nil {
+			return err
+		}
+
+		return s.record(ctx, q, description, op)
+	})
+}
+
+func writeChannelRule(
+	ctx context.Context,
+	q *database.Queries,
+	channel string,
+	rule *database.ChannelRule,
+) error {
+	if rule == nil {
+		return q.DeleteChannelRule(ctx, channel)
+	}
+
+	return q.SetChannelRule(ctx, database.SetChannelRuleParams(*rule))
+}
+
+func (s *datastore) addBookmark(
+	ctx context.Context,
+	idStr string,
+	position time.Duration,
+	note string,
+) error {
+	id, err := idStrToInt(idStr)
+	if err != nil {
+		return err
+	}
+
+	return s.queries.AddBookmark(ctx, database.AddBookmarkParams{
+		VideoID:  id,
+		Position: position.Seconds(),
+		Note:     note,
+	})
+}
+
+func (s *datastore) getBookmarks(ctx context.Context, idStr string) ([]database.Bookmark, error) {
+	id, err := idStrToInt(idStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.queries.GetBookmarks(ctx, id)
+}
+
+func (s *datastore) clearResumePosition(ctx context.Context, idStr string) error {
+	id, err := idStrToInt(idStr)
+	if err != nil {
+		return err
+	}
+
+	return s.queries.ClearResumePosition(ctx, id)
+}
+
+// videoOrder is a ranked list of videos, the library or a playlist.
+type videoOrder interface {
+	rank(ctx context.Context, id int64) (string, error)
+	setRank(ctx context.Context, id int64, rank string) error
+	// topID returns zero when the list is empty.
+	topID(ctx context.Context) (int64, error)
+	idsByRank(ctx context.Context) ([]int64, error)
+}
+
+func newVideoOrder(q *database.Queries, playlistID int64) videoOrder {
+	if playlistID == 0 {
+		return libraryOrder{q}
+	}
+
+	return playlistOrder{q, playlistID}
+}
+
+// libraryOrder is the order of all videos.
+type libraryOrder struct {
+	q *database.Queries
+}
+
+func (o libraryOrder) rank(ctx context.Context, id int64) (string, error) {
+	return o.q.GetVideoRank(ctx, id)
+}
+
+func (o libraryOrder) setRank(ctx context.Context, id int64, rank string) error {
+	return o.q.UpdateVideoRank(ctx, database.UpdateVideoRankParams{Rank: rank, ID: id})
+}
+
+func (o libraryOrder) topID(ctx context.Context) (int64, error) {
+	id, err := o.q.GetTopVideoID(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+
+	return id, err
+}
+
+func (o libraryOrder) idsByRank(ctx context.Context) ([]int64, error) {
+	return o.q.GetVideoIDsByRank(ctx)
+}
+
+// playlistOrder is the order of the videos of a playlist, independent of the
+// library order.
+type playlistOrder struct {
+	q          *database.Queries
+	playlistID int64
+}
+
+func (o playlistOrder) rank(ctx context.Context, id int64) (string, error) {
+	return o.q.GetPlaylistVideoRank(ctx, database.GetPlaylistVideoRankParams{
+		PlaylistID: o.playlistID,
+		VideoID:    id,
+	})
+}
+
+func (o playlistOrder) setRank(ctx context.Context, id int64, rank string) error {
+	return o.q.UpdatePlaylistVideoRank(ctx, database.UpdatePlaylistVideoRankParams{
+		Rank:       rank,
+		PlaylistID: o.playlistID,
+		VideoID:    id,
+	})
+}
+
+func (o playlistOrder) topID(ctx context.Context) (int64, error) {
+	id, err := o.q.GetTopPlaylistVideoID(ctx, o.playlistID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+
+	return id, err
+}
+
+func (o playlistOrder) idsByRank(ctx context.Context) ([]int64, error) {
+	return o.q.GetPlaylistVideoIDsByRank(ctx, o.playlistID)
+}
+
+// videoRankBetween returns a rank between the videos below and above, a zero
+// id is the end of the list. All ranks are spread again first when the rank
+// would get too long.
+func videoRankBetween(
+	ctx context.Context,
+	order videoOrder,
+	below, above int64,
+) (string, error) {
+	videoRank := func(id int64) (string, error) {
+		if id == 0 {
+			return "", nil
+		}
+
+		return order.rank(ctx, id)
+	}
+
+	for spread := false; ; spread = true {
+		low, err := videoRank(below)
+		if err != nil {
+			return "", err
+		}
+
+		high, err := videoRank(above)
+		if err != nil {
+			return "", err
+		}
+
+		rank := rankBetween(low, high)
+		if len(rank) <= maxRankLength || spread {
+			return rank, nil
+		}
+
+		if err := spreadVideoRanks(ctx, order); err != nil {
+			return "", fmt.Errorf("failed to spread ranks: %w", err)
+		}
+	}
+}
+
+// spreadVideoRanks gives every video an evenly spread rank in the same order.
+// Ranks are unique so they are first moved out of the way of the new ones.
+func spreadVideoRanks(ctx context.Context, order videoOrder) error {
+	ids, err := order.idsByRank(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := order.setRank(ctx, id, fmt.Sprintf("~%d", id)); err != nil {
+			return err
+		}
+	}
+
+	for i, rank := range spreadRanks(len(ids)) {
+		if err := order.setRank(ctx, ids[i], rank); err != nil {
+			return err
+		}
+	}
+
+	slog.Info("spread video ranks", slog.Int("videos", len(ids)))
+
+	return nil
+}
+
+// moveVideo ranks video id between the videos shown below and above it in the
+// library or playlist, an empty id is the end of the list.
+func (s *datastore) moveVideo(
+	ctx context.Context,
+	playlistID int64,
+	idStr, belowStr, aboveStr string,
+) error {
+	id, err := idStrToInt(idStr)
+	if err != nil {
+		return err
+	}
+
+	below, err := optionalIDStrToInt(belowStr)
+	if err != nil {
+		return err
+	}
+
+	above, err := optionalIDStrToInt(aboveStr)
+	if err != nil {
+		return err
+	}
+
+	return s.inTx(ctx, func(q *database.Queries) error {
+		order := newVideoOrder(q, playlistID)
+
+		ids, err := order.idsByRank(ctx)
+		if err != nil {
+			return err
+		}
+
+		rank, err := videoRankBetween(ctx, order, below, above)
+		if err != nil {
+			return err
+		}
+
+		if err := order.setRank(ctx, id, rank); err != nil {
+			return err
+		}
+
+		return s.record(ctx, q, "move "+describeVideos(ctx, q, []int64{id}), operation{
+			Kind:       opMove,
+			PlaylistID: playlistID,
+			Videos: []opVideo{{
+				ID:     id,
+				Before: neighbours(ids, id),
+				After:  opState{Below: below, Above: above},
+			}},
+		})
+	})
+}
+
+func (s *datastore) setWatched(ctx context.Context, idStr string) (*database.Video, error) {
+	id, err := idStrToInt(idStr)
+	if err != nil {
+		return nil, err
+	}
+
+	var video database.Video
+
+	err = s.inTx(ctx, func(q *database.Queries) error {
+		before, err := q.GetVideo(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if video, err = q.SetWatchedVideo(ctx, id); err != nil {
+			return err
+		}
+
+		if derefOr(before.IsWatched, false) {
+			return nil
+		}
+
+		return s.record(ctx, q, fmt.Sprintf("mark %s as watched", video.Name), operation{
+			Kind: opWatched,
+			Videos: []opVideo{{
+				ID:     id,
+				Before: opState{Watched: false},
+				After:  opState{Watched: true, WatchedAt: video.WatchedAt},
+			}},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &video, nil
+}
+
+func (s *datastore) toggleWatched(ctx context.Context, idStr string) (*database.Video, error) {
+	id, err := idStrToInt(idStr)
+	if err != nil {
+		return nil, err
+	}
+
+	var video database.Video
+
+	err = s.inTx(ctx, func(q *database.Queries) error {
+		before, err := q.GetVideo(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if video, err = q.ToggleWatchedStatus(ctx, id); err != nil {
+			return err
+		}
+
+		watched := derefOr(video.IsWatched, false)
+		status := "unwatched"
+
+		if watched {
+			status = "watched"
+		}
+
+		return s.record(ctx, q, fmt.Sprintf("mark %s as %s", video.Name, status), operation{
+			Kind: opWatched,
+			Videos: []opVideo{{
+				ID:     id,
+				Before: opState{Watched: !watched, WatchedAt: before.WatchedAt},
+				After:  opState{Watched: watched, WatchedAt: video.WatchedAt},
+			}},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &video, nil
+}
+
+func (s *datastore) setChannel(
+	ctx context.Context,
+	idStr, channel string,
+) (*database.Video, error) {
+	id, err := idStrToInt(idStr)
+	if err != nil {
+		return nil, err
+	}
+
+	var video database.Video
+
+	err = s.inTx(ctx, func(q *database.Queries) error {
+		before, err := q.GetVideo(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if video, err = q.SetVideoChannel(ctx, database.SetVideoChannelParams{
+			Channel: &channel,
+			ID:      id,
+		}); err != nil {
+			return err
+		}
+
+		return s.record(ctx, q, fmt.Sprintf("set channel of %s", video.Name), operation{
+			Kind: opChannel,
+			Videos: []opVideo{{
+				ID:     id,
+				Before: opState{Channel: before.Channel},
+				After:  opState{Channel: video.Channel},
+			}},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &video, nil
+}
+
+// toggleKeep marks the video to keep from the retention rules, or not.
+func (s *datastore) toggleKeep(ctx context.Context, idStr string) (*database.Video, error) {
+	id, err := idStrToInt(idStr)
+	if err != nil {
+		return nil, err
+	}
+
+	var video database.Video
+
+	err = s.inTx(ctx, func(q *database.Queries) error {
+		if video, err = q.ToggleVideoKeep(ctx, id); err != nil {
+			return err
+		}
+
+		description := "keep " + video.Name
+		if !video.Keep {
+			description = "stop keeping " + video.Name
+		}
+
+		return s.record(ctx, q, description, operation{
+			Kind: opKeep,
+			Videos: []opVideo{{
+				ID:     id,
+				Before: opState{Keep: !video.Keep},
+				After:  opState{Keep: video.Keep},
+			}},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &video, nil
+}
+
+func (s *datastore) deleteVideo(ctx context.Context, id int64) error {
+	return s.queries.DeleteVideo(ctx, id)
+}
+
+// trashedFile is where the file of a video was moved to in the trash from,
+// path is empty when the video had no file.
+type trashedFile struct {
+	videoID string
+	from    string
+	path    string
+}
+
+// trashVideos hides the videos until they are restored or purged.
+func (s *datastore) trashVideos(ctx context.Context, files []trashedFile) error {
+	return s.inTx(ctx, func(q *database.Queries) error {
+		op := operation{Kind: opTrash}
+
+		for _, file := range files {
+			id, err := idStrToInt(file.videoID)
+			if err != nil {
+				return err
+			}
+
+			params := database.TrashVideoParams{ID: id}
+			if file.path != "" {
+				params.TrashPath = &file.path
+			}
+
+			if err := q.TrashVideo(ctx, params); err != nil {
+				return err
+			}
+
+			op.Videos = append(op.Videos, opVideo{ID: id})
+		}
+
+		description := fmt.Sprintf("move %s to trash", describeVideos(ctx, q, op.videoIDs()))
+
+		return s.record(ctx, q, description, op)
+	})
+}
+
+// retireVideos hides the videos whose files retention deleted, they stay in
+// the trash without a file. It is not recorded as the files cannot come back.
+func (s *datastore) retireVideos(ctx context.Context, ids []int64) error {
+	return s.inTx(ctx, func(q *database.Queries) error {
+		for _, id := range ids {
+			if err := q.TrashVideo(ctx, database.TrashVideoParams{ID: id}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// moveVideoFiles points the videos to the files retention moved out of the
+// library, undoing moves the files back.
+func (s *datastore) moveVideoFiles(ctx context.Context, moved []movedVideo, dir string) error {
+	return s.inTx(ctx, func(q *database.Queries) error {
+		op := operation{Kind: opMoveFiles}
+
+		for _, m := range moved {
+			if err := relinkVideo(ctx, q, m.video.ID, m.file.path); err != nil {
+				return err
+			}
+
+			op.Videos = append(op.Videos, opVideo{
+				ID:     m.video.ID,
+				Before: opState{Path: filepath.Join(m.video.Location, m.video.Name)},
+				After:  opState{Path: m.file.path},
+			})
+		}
+
+		description := fmt.Sprintf(
+			"move %s to %s",
+			describeVideos(ctx, q, op.videoIDs()),
+			shortenPath(dir),
+		)
+
+		return s.record(ctx, q, description, op)
+	})
+}
+
+// restoreVideos restores the trashed videos, the names of the ones restored
+// are returned.
+func (s *datastore) restoreVideos(ctx context.Context, ids []int64) ([]string, error) {
+	names := make([]string, 0, len(ids))
+	op := operation{Kind: opRestore}
+
+	var err error
+
+	for _, id := range ids {
+		var name string
+		if name, err = s.restoreTrashedVideo(ctx, id); err != nil {
+			break
+		}
+
+		if name != "" {
+			names = append(names, name)
+			op.Videos = append(op.Videos, opVideo{ID: id})
+		}
+	}
+
+	description := fmt.Sprintf("restore %s", describeVideos(ctx, s.queries, op.videoIDs()))
+	if recordErr := s.record(ctx, s.queries, description, op); err == nil {
+		err = recordErr
+	}
+
+	return names, err
+}
+
+func relinkVideo(ctx context.Context, q *database.Queries, id int64, path string) error {
+	return q.RelinkVideo(ctx, database.RelinkVideoParams{
+		Name:     filepath.Base(path),
+		Location: filepath.Dir(path),
+		Size:     fileSize(path),
+		ID:       id,
+	})
+}
+
+// relinkVideos points the videos to the files they were moved to.
+func (s *datastore) relinkVideos(ctx context.Context, moved []movedVideo) error {
+	return s.inTx(ctx, func(q *database.Queries) error {
+		op := operation{Kind: opRelink}
+
+		for _, m := range moved {
+			if err := relinkVideo(ctx, q, m.video.ID, m.file.path); err != nil {
+				return err
+			}
+
+			op.Videos = append(op.Videos, opVideo{
+				ID:     m.video.ID,
+				Before: opState{Path: filepath.Join(m.video.Location, m.video.Name)},
+				After:  opState{Path: m.file.path},
+			})
+		}
+
+		description := fmt.Sprintf("relink %s", describeVideos(ctx, q, op.videoIDs()))
+
+		return s.record(ctx, q, description, op)
+	})
+}
+
+// backfillSizes stores the file size of the videos added before it was
+// stored, and returns how many got one.
+func (s *datastore) backfillSizes(ctx context.Context) (int, error) {
+	videos, err := s.queries.GetVideosWithoutSize(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+
+	for _, video := range videos {
+		size := fileSize(filepath.Join(video.Location, video.Name))
+		if size == nil {
+			continue
+		}
+
+		if err := s.queries.SetVideoSize(ctx, database.SetVideoSizeParams{
+			Size: size,
+			ID:   video.ID,
+		}); err != nil {
+			return count, err
+		}
+
+		count++
+	}
+
+	return count, nil
+}
+
+// replaceVideoFile points the video to its file downloaded again, the
+// sponsor segments and subtitles of the new download replace the old ones.
+// The paths of the old subtitle files not downloaded again are returned.
+func (s *datastore) replaceVideoFile(
+	ctx context.Context,
+	id int64,
+	path string,
+	segments []sponsorSegment,
+	subtitles []downloadedSubtitle,
+) (*database.Video, []string, error) {
+	cues := readSubtitleCues(ctx, path, subtitles)
+
+	var (
+		video database.Video
+		stale []string
+	)
+
+	err := s.inTx(ctx, func(q *database.Queries) error {
+		old, err := q.GetSubtitles(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		for _, subtitle := range old {
+			downloaded := slices.ContainsFunc(subtitles, func(s downloadedSubtitle) bool {
+				return s.path == subtitle.Path
+			})
+
+			if subtitle.Path != "" && !downloaded {
+				stale = append(stale, subtitle.Path)
+			}
+		}
+
+		if err := relinkVideo(ctx, q, id, path); err != nil {
+			return err
+		}
+
+		if err := q.DeleteSegments(ctx, id); err != nil {
+			return err
+		}
+
+		if err := addSegments(ctx, q, id, segments); err != nil {
+			return err
+		}
+
+		if err := q.DeleteSubtitles(ctx, id); err != nil {
+			return err
+		}
+
+		if err := addSubtitles(ctx, q, id, subtitles); err != nil {
+			return err
+		}
+
+		if err := q.DeleteSubtitleCues(ctx, id); err != nil {
+			return err
+		}
+
+		if err := addSubtitleCues(ctx, q, id, cues); err != nil {
+			return err
+		}
+
+		video, err = q.GetVideo(ctx, id)
+
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &video, stale, nil
+}
+
+func (s *datastore) getTrashedVideos(ctx context.Context) ([]database.Video, error) {
+	return s.queries.GetTrashedVideos(ctx)
+}
+
+func (s *datastore) getSetting(ctx context.Context, key string) (string, error) {
+	value, err := s.queries.GetSetting(ctx, key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	return value, nil
+}
+
+func (s *datastore) setSetting(ctx context.Context, key, value string) error {
+	return s.queries.SetSetting(ctx, database.SetSettingParams{Key: key, Value: value})
 }
 
 func (s *datastore) Close() error {
@@ -1,10 +1,14 @@
 package main
 
 import (
+	"database/sql"
+	"fmt"
+	"slices"
 	"strings"
 	"sync"
 
 	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -27,7 +31,14 @@ const (
 	colName     column = "Name"
 	colURL      column = "URL"
 	colLocation column = "Location"
-	colOrder    column = "Order"
+	colChannel  column = "Channel"
+	colResume   column = "Resume"
+	colTags     column = "Tags"
+	colAdded    column = "Added"
+	colUploaded column = "Uploaded"
+	colDuration column = "Duration"
+	colSize     column = "Size"
+	colKeep     column = "Keep"
 )
 
 type row map[column]string
@@ -48,6 +59,14 @@ type datatable struct {
 	columns             []column
 	rowMu               sync.RWMutex
 	rows                []row
+	playlist            database.Playlist
+	sort                tableSort
+	filter              tagFilter
+	searchInput         textinput.Model
+	searchOrigin        int
+	searchMu            sync.RWMutex
+	search              tableSearch
+	matchStyle          lipgloss.Style
 	updateRowOrderTagMu sync.RWMutex
 	updateRowOrderTag   int
 	cursorMu            sync.RWMutex
@@ -57,10 +76,24 @@ type datatable struct {
 	selectModeStart     int
 	isFocused           bool
 	player              *player
+	clipper             *clipper
+	downloadDir         string
+	retention           retentionPolicy
+	upNext              *upNextQueue
 	deleteConfirm       bool
 }
 
-func newDatatable(player *player, queries *database.Queries, getCtx contextFn) *datatable {
+func newDatatable(
+	player *player,
+	db *sql.DB,
+	queries *database.Queries,
+	clipper *clipper,
+	upNext *upNextQueue,
+	downloadDir string,
+	retention retentionPolicy,
+	historyDepth int,
+	getCtx contextFn,
+) *datatable {
 	// minus topbar, urlPrompt, downloaderView, datatable's header (include borders)
 	const defaultViewportHeight = minHeight - 1 - 3 - 4 - 4
 
@@ -69,18 +102,25 @@ func newDatatable(player *player, queries *database.Queries, getCtx contextFn) *
 		isInitialRefresh: true,
 		widths:           make(map[column]int),
 		getCtx:           getCtx,
-		datastore:        newDatastore(queries),
+		datastore:        newDatastore(db, queries),
 		viewport:         viewport.New(0, defaultViewportHeight),
 		headerStyle:      lipgloss.NewStyle().Bold(true),
 		selectedRowStyle: lipgloss.NewStyle().
 			Background(lipgloss.Color("244")).
 			Foreground(lipgloss.Color("229")),
 		focusedBGColor: lipgloss.Color("141"),
+		searchInput:    newSearchInput(),
+		matchStyle:     lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Underline(true),
 		styles:         styles,
 		keymap:         newDatatableKeymap(),
-		columns:        []column{colWatched, colName, colURL, colLocation},
+		columns:        []column{colWatched, colName, colURL, colLocation, colTags},
 		player:         player,
+		clipper:        clipper,
+		downloadDir:    downloadDir,
+		retention:      retention,
+		upNext:         upNext,
 	}
+	d.datastore.historyDepth = historyDepth
 
 	return d
 }
@@ -95,6 +135,7 @@ func (d *datatable) calculateColWidth() {
 	d.widths[colName] = defaultWidth + colPadding
 	d.widths[colURL] = defaultWidth + colPadding
 	d.widths[colLocation] = defaultWidth + colPadding
+	d.widths[colTags] = defaultWidth + colPadding
 	d.widths[colWatched] = isWatchedColWidth + colPadding
 }
 
@@ -108,6 +149,11 @@ func (d *datatable) getIDAtIndex(idx int) string {
 	d.rowMu.RLock()
 	defer d.rowMu.RUnlock()
 
+	// the table is empty when the tag filter matches nothing
+	if idx < 0 || idx >= len(d.rows) {
+		return ""
+	}
+
 	return d.rows[idx][colID]
 }
 
@@ -138,13 +184,52 @@ func (d *datatable) isSelected(r int) bool {
 	return d.selectMode && r == clamp(r, d.selectModeStart, d.cursor)
 }
 
+// isRowShown reports whether r passes the tag filter and, in filter mode, the
+// search.
+func (d *datatable) isRowShown(r row) bool {
+	if filter := d.getTagFilter(); filter.active() && !filter.matches(r) {
+		return false
+	}
+
+	if search := d.getSearch(); search.filterOnly && search.active() {
+		_, ok := matchRow(search.query, r)
+
+		return ok
+	}
+
+	return true
+}
+
+func (d *datatable) filterRows(rows []row) []row {
+	return slices.DeleteFunc(rows, func(r row) bool { return !d.isRowShown(r) })
+}
+
+// targetRows returns the selected rows, or the row at cursor when not in
+// select mode. It must not be called while holding the cursorMu lock.
+func (d *datatable) targetRows(cursor int) []row {
+	d.selectModeMu.RLock()
+	defer d.selectModeMu.RUnlock()
+
+	rows := make([]row, 0)
+
+	for idx, row := range d.getCopyOfRows() {
+		if (idx == cursor && !d.selectMode) || d.isSelected(idx) {
+			rows = append(rows, row)
+		}
+	}
+
+	return rows
+}
+
 func (d *datatable) updateViewport() {
 	d.rowMu.RLock()
 	defer d.rowMu.RUnlock()
 
+	columns := d.shownColumns(d.sort)
 	renderedRows := make([]string, 0, len(d.rows))
+
 	for i := range d.rows {
-		renderedRows = append(renderedRows, d.renderRow(i))
+		renderedRows = append(renderedRows, d.renderRow(i, columns))
 	}
 
 	d.viewport.SetContent(lipgloss.JoinVertical(lipgloss.Left, renderedRows...))
@@ -155,26 +240,38 @@ func (d *datatable) setRows(rows []row) {
 	d.rows = rows
 	d.rowMu.Unlock()
 
+	d.updateSearchMatches(rows)
 	d.updateViewport()
 }
 
 func (d *datatable) Init() tea.Cmd {
-	return d.refreshRowsCmd()
+	cmds := []tea.Cmd{
+		tea.Sequence(d.loadPlaylistCmd(), d.backfillSizesCmd()),
+		d.loadPlayModeCmd(),
+		d.scanLibraryCmd(scanStartup),
+		d.indexStoredSubtitlesCmd(),
+	}
+
+	if d.retention.enabled() {
+		cmds = append(cmds, d.applyRetentionCmd(), d.scheduleRetentionCmd())
+	}
+
+	return tea.Batch(cmds...)
 }
 
 func (d *datatable) deletedMultiRowsFooterStr(msg deletedMultipleRowsMsg) string {
 	var footerMsg strings.Builder
 
-	footerMsg.WriteString("Deleted videos: \n")
+	footerMsg.WriteString("Moved videos to trash (u to undo): \n")
 
 	for _, filename := range msg.filenames {
 		footerMsg.WriteString(filename + ", ")
 	}
 
-	if len(msg.notFounds) != 0 {
-		footerMsg.WriteString("\nVideos not found, removed entries from database: \n")
+	if len(msg.failed) != 0 {
+		footerMsg.WriteString("\nFailed to move video files to trash: \n")
 
-		for _, filename := range msg.notFounds {
+		for _, filename := range msg.failed {
 			footerMsg.WriteString(filename + ", ")
 		}
 	}
@@ -193,13 +290,32 @@ func (d *datatable) deletedMultiRowsFooterStr(msg deletedMultipleRowsMsg) string
 func (d *datatable) Update(msg tea.Msg) (*datatable, tea.Cmd) {
 	var cmds []tea.Cmd
 
+	if _, ok := msg.(tea.KeyMsg); !ok && d.searchInput.Focused() {
+		var cmd tea.Cmd
+		d.searchInput, cmd = d.searchInput.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		d.width = msg.Width - d.styles.GetHorizontalFrameSize()
 		d.viewport.Width = d.width
+		d.searchInput.Width = d.width - dtCellPadding*2 - lipgloss.Width(d.searchInput.Prompt) - 1
 		d.calculateColWidth()
 	case finishDownloadMsg:
-		cmds = append(cmds, d.newVideoCmd(msg.filename, msg.url, msg.downloadPath))
+		if msg.videoID != 0 {
+			cmds = append(cmds, d.replaceFileCmd(msg))
+		} else {
+			cmds = append(cmds, d.newVideoCmd(msg))
+		}
+	case redownloadedMsg:
+		cmds = append(cmds, footerMsgCmd("Downloaded again, cannot be undone: "+msg.name, 0))
+	case clipExportedMsg:
+		cmds = append(cmds, footerMsgCmd("Exported clip "+msg.name, 0))
+	case resumePositionUsedMsg:
+		cmds = append(cmds, d.clearResumePositionCmd(msg.id))
+	case skipVideoMsg:
+		cmds = append(cmds, d.skipVideoCmd(msg.previous))
 	case finishPlayingMsg:
 		cmds = append(cmds, d.playNextOrStopCmd())
 	case updateRowOrderMsg:
@@ -210,6 +326,29 @@ func (d *datatable) Update(msg tea.Msg) (*datatable, tea.Cmd) {
 		d.updateRowOrderTagMu.RUnlock()
 	case playbackChangedMsg, updatedRowOrderMsg:
 		d.updateViewport()
+	case searchFilterMsg:
+		if d.getSearch().filterTag == msg.tag {
+			cmds = append(cmds, d.reloadRowsCmd())
+		}
+	case rowsReloadedMsg:
+		d.cursorMu.RLock()
+		d.rowMu.RLock()
+		d.scrollUp()
+		d.scrollDown()
+		d.rowMu.RUnlock()
+		d.cursorMu.RUnlock()
+	case segmentSkippedMsg:
+		footerMsg := fmt.Sprintf(
+			"skipped %s (%s), press U to undo",
+			msg.category,
+			msg.duration,
+		)
+		cmds = append(cmds, footerMsgCmd(footerMsg, 0))
+	case playerExitedMsg:
+		d.updateViewport()
+
+		err := fmt.Errorf("mpv exited unexpectedly, press R to restart: %w", msg.err)
+		cmds = append(cmds, errorCmd(err))
 	case sectionChangedMsg:
 		d.isFocused = msg.section == sectionDatatable
 		if msg.section == sectionDatatable {
@@ -218,15 +357,49 @@ func (d *datatable) Update(msg tea.Msg) (*datatable, tea.Cmd) {
 			d.styles = d.styles.UnsetBorderForeground()
 		}
 	case deletedRowMsg:
-		footerMsg := "Deleted video: " + msg.filename
+		footerMsg := "Moved to trash (u to undo): " + msg.filename
 		if msg.notFound {
-			footerMsg = "Video not found, removed entry from database: " + msg.filename
+			footerMsg = "Video file not found, moved entry to trash: " + msg.filename
 		}
 
 		cmds = append(cmds, footerMsgCmd(footerMsg, 0))
 	case deletedMultipleRowsMsg:
 		footerMsg := d.deletedMultiRowsFooterStr(msg)
 		cmds = append(cmds, footerMsgCmd(footerMsg, 0), d.toggleSelectModeCmd())
+	case restoredVideosMsg:
+		cmds = append(cmds, d.reloadRowsCmd(), footerMsgCmd(restoredFooterStr(msg.names), 0))
+
+		if msg.err != nil {
+			cmds = append(cmds, errorCmd(msg.err))
+		}
+	case historyStepMsg:
+		action := "Redone: "
+		if msg.undo {
+			action = "Undone: "
+		}
+
+		cmds = append(cmds, d.reloadRowsCmd(), footerMsgCmd(action+msg.description, 0))
+	case reconciledMsg:
+		cmds = append(cmds, d.reloadRowsCmd(), footerMsgCmd(msg.description, 0))
+
+		if msg.err != nil {
+			cmds = append(cmds, errorCmd(msg.err))
+		} else {
+			cmds = append(cmds, d.scanLibraryCmd(scanContinue))
+		}
+	case retentionTickMsg:
+		cmds = append(cmds, d.applyRetentionCmd(), d.scheduleRetentionCmd())
+	case retentionAppliedMsg:
+		if msg.count > 0 {
+			cmds = append(cmds, d.reloadRowsCmd(), footerMsgCmd(d.retentionFooterStr(msg), 0))
+		}
+
+		if msg.err != nil {
+			cmds = append(cmds, errorCmd(fmt.Errorf("retention: %w", msg.err)))
+		}
+	case purgedVideosMsg:
+		footer := fmt.Sprintf("Deleted %d videos permanently, this cannot be undone", msg.count)
+		cmds = append(cmds, footerMsgCmd(footer, 0))
 	case updateProgressMsg:
 		cmds = append(cmds, d.player.progress.SetPercent(msg.percent))
 	case progress.FrameMsg:
@@ -1,18 +1,21 @@
 package main
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"math/rand/v2"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"slices"
-	"strconv"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/linnovs/ytqueue/database"
 )
 
 type deletedRowMsg struct {
@@ -22,7 +25,7 @@ type deletedRowMsg struct {
 
 type deletedMultipleRowsMsg struct {
 	filenames []string
-	notFounds []string
+	failed    []string
 	dbErrors  []error
 }
 
@@ -33,6 +36,10 @@ type updateRowOrderMsg struct {
 
 type updatedRowOrderMsg struct{}
 
+type resumePositionUsedMsg struct {
+	id string
+}
+
 const (
 	wlCopyCmd  = "wl-copy"
 	wlPasteCmd = "wl-paste"
@@ -43,6 +50,10 @@ func (d *datatable) copyURLCmd(cursor int) tea.Cmd {
 		d.rowMu.RLock()
 		defer d.rowMu.RUnlock()
 
+		if cursor >= len(d.rows) {
+			return nil
+		}
+
 		url := d.rows[cursor][colURL]
 
 		// #nosec G204
@@ -56,6 +67,33 @@ func (d *datatable) copyURLCmd(cursor int) tea.Cmd {
 	}
 }
 
+// copyTimestampedURLCmd copies the URL of the playing video pointing at the
+// current playback position.
+func (d *datatable) copyTimestampedURLCmd() tea.Cmd {
+	return func() tea.Msg {
+		d.rowMu.RLock()
+		defer d.rowMu.RUnlock()
+
+		id := d.player.getCurrentlyPlayingId()
+
+		idx := slices.IndexFunc(d.rows, playingIDIndexFunc(id))
+		if !d.player.isRunning() || id == "" || idx < 0 {
+			return footerMsgCmd("Nothing is playing", 0)()
+		}
+
+		url := timestampedURL(d.rows[idx][colURL], d.player.getPlaytime())
+
+		// #nosec G204
+		if err := exec.Command(wlCopyCmd, url).Run(); err != nil {
+			return errorMsg{fmt.Errorf("failed to copy URL to clipboard: %w", err)}
+		}
+
+		const msgDelay = 2 * time.Second
+
+		return footerMsgCmd(fmt.Sprintf("Copied URL (%s) to clipboard", url), msgDelay)()
+	}
+}
+
 func (d *datatable) pasteURLCmd() tea.Cmd {
 	return func() tea.Msg {
 		d.rowMu.RLock()
@@ -70,19 +108,40 @@ func (d *datatable) pasteURLCmd() tea.Cmd {
 
 		slog.Debug("pasted URL from clipboard", slog.String("url", url))
 
-		return submitURLMsg{url}
+		return submitURLCmd(url)()
 	}
 }
 
+// loadRows shows the videos of the active playlist matching the tag filter in
+// the sort order.
+func (d *datatable) loadRows() error {
+	videos, err := d.datastore.getVideos(d.getCtx(), d.getPlaylist().ID)
+	if err != nil {
+		return err
+	}
+
+	tags, err := d.datastore.getVideoTags(d.getCtx())
+	if err != nil {
+		return fmt.Errorf("failed to load tags: %w", err)
+	}
+
+	rows := d.filterRows(videosToRows(videos, tags))
+	d.getSort().apply(rows)
+	d.setRows(rows)
+
+	d.cursorMu.Lock()
+	d.cursor = clamp(d.cursor, 0, max(len(rows)-1, 0))
+	d.cursorMu.Unlock()
+
+	return nil
+}
+
 func (d *datatable) refreshRowsCmd() tea.Cmd {
 	return tea.Sequence(func() tea.Msg {
-		videos, err := d.datastore.getVideos(d.getCtx())
-		if err != nil {
+		if err := d.loadRows(); err != nil {
 			return errorMsg{err: err}
 		}
 
-		d.setRows(videosToRows(videos))
-
 		return nil
 	}, func() tea.Msg {
 		if d.isInitialRefresh {
@@ -95,27 +154,67 @@ func (d *datatable) refreshRowsCmd() tea.Cmd {
 	})
 }
 
-func (d *datatable) newVideoCmd(name, url, location string) tea.Cmd {
+func (d *datatable) newVideoCmd(msg finishDownloadMsg) tea.Cmd {
 	return func() tea.Msg {
-		video, err := d.datastore.addVideo(d.getCtx(), name, url, location)
+		// a video downloaded while a playlist is shown is added to it as well
+		video, err := d.datastore.addVideo(d.getCtx(), newVideo{
+			name:       msg.filename,
+			url:        msg.url,
+			location:   msg.downloadPath,
+			channel:    msg.channel,
+			uploadDate: msg.uploadDate,
+			duration:   msg.duration,
+			start:      msg.start,
+			playlistID: d.getPlaylist().ID,
+		})
 		if err != nil {
 			return errorMsg{err}
 		}
 
-		oldId := d.getCursorID()
-		rows := append([]row{videoToRow(*video)}, d.getCopyOfRows()...)
+		if err := d.datastore.addSegments(d.getCtx(), video.ID, msg.segments); err != nil {
+			return errorMsg{fmt.Errorf("failed to save sponsorblock segments: %w", err)}
+		}
 
-		d.setRows(rows)
+		if err := d.datastore.addSubtitles(d.getCtx(), video.ID, msg.subtitles); err != nil {
+			return errorMsg{fmt.Errorf("failed to save subtitles: %w", err)}
+		}
 
-		d.cursorMu.Lock()
-		idx := slices.IndexFunc(rows, playingIDIndexFunc(oldId))
-		d.cursor = clamp(idx, 0, len(rows)-1)
-		d.cursorMu.Unlock()
+		videoPath := filepath.Join(video.Location, video.Name)
+		if err := d.datastore.indexSubtitles(
+			d.getCtx(),
+			video.ID,
+			videoPath,
+			msg.subtitles,
+		); err != nil {
+			return errorMsg{fmt.Errorf("failed to index subtitles: %w", err)}
+		}
+
+		d.prependRow(*video)
 
 		return nil
 	}
 }
 
+// prependRow adds the row of a new video on top, or where the sort puts it,
+// keeping the cursor on the row it was on.
+func (d *datatable) prependRow(video database.Video) {
+	r := videoToRow(video)
+	if !d.isRowShown(r) {
+		return
+	}
+
+	oldId := d.getCursorID()
+	rows := append([]row{r}, d.getCopyOfRows()...)
+	d.getSort().apply(rows)
+
+	d.setRows(rows)
+
+	d.cursorMu.Lock()
+	idx := slices.IndexFunc(rows, playingIDIndexFunc(oldId))
+	d.cursor = clamp(idx, 0, len(rows)-1)
+	d.cursorMu.Unlock()
+}
+
 func (d *datatable) playStopRowCmd(id string) tea.Cmd {
 	return func() tea.Msg {
 		d.rowMu.RLock()
@@ -123,6 +222,10 @@ func (d *datatable) playStopRowCmd(id string) tea.Cmd {
 
 		slog.Debug("playStopRowCmd", slog.String("requestedId", id))
 
+		if id == "" {
+			return nil
+		}
+
 		if d.player.isRunning() && d.player.getCurrentlyPlayingId() == id && d.player.isPlaying() {
 			if err := d.player.stop(); err != nil {
 				return errorMsg{err: fmt.Errorf("failed to stop player: %w", err)}
@@ -131,40 +234,165 @@ func (d *datatable) playStopRowCmd(id string) tea.Cmd {
 			return nil
 		}
 
-		idx := slices.IndexFunc(d.rows, playingIDIndexFunc(id))
-		if idx == -1 {
-			return errorMsg{errors.New("playing video not found in datatable")}
+		return d.playRow(id, 0)
+	}
+}
+
+var errVideoGone = errors.New("video no longer exists")
+
+// videoRow returns the row of video id, a video hidden by the playlist, filter
+// or search shown is loaded from the database.
+// this function assumes the caller holds the rowMu Rlock.
+func (d *datatable) videoRow(id string) (row, error) {
+	if idx := slices.IndexFunc(d.rows, playingIDIndexFunc(id)); idx >= 0 {
+		return d.rows[idx], nil
+	}
+
+	video, err := d.datastore.getVideo(d.getCtx(), id)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && video.DeletedAt != nil) {
+		return nil, errVideoGone
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to load video: %w", err)
+	}
+
+	return videoToRow(*video), nil
+}
+
+// popUpNext takes the next video of the up next queue, videos deleted since
+// they were queued are dropped.
+// this function assumes the caller holds the rowMu Rlock.
+func (d *datatable) popUpNext() (string, bool) {
+	for item, ok := d.upNext.pop(); ok; item, ok = d.upNext.pop() {
+		if _, err := d.videoRow(item.id); errors.Is(err, errVideoGone) {
+			continue
 		}
 
-		slog.Debug(
-			"playing video found",
-			slog.Int("index", idx),
-			slog.Int("totalRows", len(d.rows)),
-		)
+		return item.id, true
+	}
 
-		row := d.rows[idx]
-		file := filepath.Join(row[colLocation], row[colName])
-		file = filepath.Clean(file)
+	return "", false
+}
 
-		_, err := os.Stat(file)
-		if err != nil {
-			if errors.Is(err, os.ErrNotExist) {
-				return errorMsg{errors.New("file does not exist")}
+// playRow plays the video of row id from start, a zero start uses the position
+// from the submitted URL on the first play, otherwise mpv resumes from its
+// watch later config or the channel rule.
+// this function assumes the caller holds the rowMu Rlock.
+func (d *datatable) playRow(id string, start time.Duration) tea.Msg {
+	row, err := d.videoRow(id)
+	if err != nil {
+		return errorMsg{err}
+	}
+
+	file := filepath.Join(row[colLocation], row[colName])
+	file = filepath.Clean(file)
+
+	if _, err := os.Stat(file); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return errorMsg{errors.New("file does not exist")}
+		}
+
+		return errorMsg{err: fmt.Errorf("failed to access file: %w", err)}
+	}
+
+	segments, err := d.datastore.getSegments(d.getCtx(), id)
+	if err != nil {
+		return errorMsg{fmt.Errorf("failed to load sponsorblock segments: %w", err)}
+	}
+
+	rule, err := d.datastore.getChannelRule(d.getCtx(), row[colChannel])
+	if err != nil {
+		return errorMsg{fmt.Errorf("failed to load channel rule: %w", err)}
+	}
+
+	slog.Debug("starting to play video", slog.String("id", id), slog.String("file", file))
+
+	resume := start == 0 && row[colResume] != ""
+	if resume {
+		start = parseSeconds(row[colResume])
+	}
+
+	opts := newPlayOptions(segments, rule)
+	if start > 0 {
+		opts.start = start
+	}
+
+	if err := d.player.play(file, id, opts); err != nil {
+		return errorMsg{err: fmt.Errorf("failed to play file: %w", err)}
+	}
+
+	if resume {
+		return resumePositionUsedMsg{id}
+	}
+
+	return nil
+}
+
+// playAtCmd seeks to position when the video is loaded in the player or
+// starts playing it from there otherwise.
+func (d *datatable) playAtCmd(id string, position time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		if d.player.isRunning() && d.player.getCurrentlyPlayingId() == id {
+			if err := d.player.sendMPVCommand("seek", position.Seconds(), "absolute"); err != nil {
+				return errorMsg{fmt.Errorf("failed to seek: %w", err)}
 			}
 
-			return errorMsg{err: fmt.Errorf("failed to access file: %w", err)}
+			return nil
 		}
 
-		slog.Debug("starting to play video", slog.String("id", id), slog.String("file", file))
+		d.rowMu.RLock()
+		defer d.rowMu.RUnlock()
+
+		return d.playRow(id, position)
+	}
+}
 
-		if err := d.player.play(file, id); err != nil {
-			return errorMsg{err: fmt.Errorf("failed to play file: %w", err)}
+// clearResumePositionCmd forgets the position from the submitted URL once it
+// was used so that later plays resume where the video was left.
+func (d *datatable) clearResumePositionCmd(id string) tea.Cmd {
+	return func() tea.Msg {
+		if err := d.datastore.clearResumePosition(d.getCtx(), id); err != nil {
+			return errorMsg{fmt.Errorf("failed to clear resume position: %w", err)}
 		}
 
+		rows := d.getCopyOfRows()
+
+		idx := slices.IndexFunc(rows, playingIDIndexFunc(id))
+		if idx < 0 {
+			return nil
+		}
+
+		rows[idx] = maps.Clone(rows[idx])
+		rows[idx][colResume] = ""
+		d.setRows(rows)
+
 		return nil
 	}
 }
 
+func (d *datatable) restartPlayerCmd() tea.Cmd {
+	return func() tea.Msg {
+		if d.player.isRunning() {
+			return nil
+		}
+
+		if err := d.player.restart(); err != nil {
+			return errorMsg{fmt.Errorf("failed to restart player: %w", err)}
+		}
+
+		return footerMsgCmd("Restarted player", 0)()
+	}
+}
+
+func (d *datatable) sleepTimerPopupCmd() tea.Cmd {
+	return openPopupCmd(newPopup("Sleep timer", sleepTimerPopupItems(), func(item popupItem) tea.Cmd {
+		opt, _ := item.value.(sleepTimerOption)
+
+		return d.player.setSleepTimer(opt)
+	}))
+}
+
 func (d *datatable) setVideoWatched(id string) (int, error) {
 	video, err := d.datastore.setWatched(d.getCtx(), id)
 	if err != nil {
@@ -172,13 +400,117 @@ func (d *datatable) setVideoWatched(id string) (int, error) {
 	}
 
 	rows := d.getCopyOfRows()
+
 	idx := slices.IndexFunc(rows, playingIDIndexFunc(id))
-	rows[idx] = videoToRow(*video)
+	if idx < 0 {
+		return idx, nil
+	}
+
+	rows[idx] = updatedRow(rows[idx], *video)
 	d.setRows(rows)
 
 	return idx, nil
 }
 
+// backfillSizesCmd stores the file size of the videos added before it was
+// stored, the rows are reloaded to show them.
+func (d *datatable) backfillSizesCmd() tea.Cmd {
+	return func() tea.Msg {
+		count, err := d.datastore.backfillSizes(d.getCtx())
+		if err != nil {
+			return errorMsg{fmt.Errorf("failed to store file sizes: %w", err)}
+		}
+
+		if count == 0 {
+			return nil
+		}
+
+		return d.reloadRowsCmd()()
+	}
+}
+
+func (d *datatable) loadPlayModeCmd() tea.Cmd {
+	return func() tea.Msg {
+		mode, err := d.datastore.getSetting(d.getCtx(), playModeSettingKey)
+		if err != nil {
+			return errorMsg{fmt.Errorf("failed to load play mode: %w", err)}
+		}
+
+		d.player.setPlayMode(parsePlayMode(mode))
+
+		return nil
+	}
+}
+
+func (d *datatable) cyclePlayModeCmd() tea.Cmd {
+	return func() tea.Msg {
+		mode := d.player.getPlayMode().next()
+		d.player.setPlayMode(mode)
+
+		if err := d.datastore.setSetting(d.getCtx(), playModeSettingKey, mode.key()); err != nil {
+			return errorMsg{fmt.Errorf("failed to save play mode: %w", err)}
+		}
+
+		return footerMsgCmd("Play mode: "+mode.String(), 0)()
+	}
+}
+
+// nextRowIndex picks the row to play after the row at idx according to the
+// play mode, it returns -1 when playback should stop.
+// this function assumes the caller holds the rowMu Rlock.
+func (d *datatable) nextRowIndex(idx int, mode playMode) int {
+	isUnwatched := func(i int) bool { return d.rows[i][colWatched] == isWatchedNo }
+
+	switch mode {
+	case playModeSequentialUp:
+		for i := idx - 1; i >= 0; i-- {
+			if isUnwatched(i) {
+				return i
+			}
+		}
+	case playModeSequentialDown:
+		for i := idx + 1; i < len(d.rows); i++ {
+			if isUnwatched(i) {
+				return i
+			}
+		}
+	case playModeShuffle:
+		candidates := make([]int, 0, len(d.rows))
+
+		for i := range d.rows {
+			if i != idx && isUnwatched(i) {
+				candidates = append(candidates, i)
+			}
+		}
+
+		if len(candidates) > 0 {
+			return candidates[rand.IntN(len(candidates))] // #nosec G404
+		}
+	case playModeRepeatOne:
+		return idx
+	case playModeRepeatAll:
+		return (idx - 1 + len(d.rows)) % len(d.rows)
+	case playModeStopAfterCurrent, playModeCount:
+	}
+
+	return -1
+}
+
+// stoppedMsg tells that the queue is drained when playback stopped with no
+// unwatched video left, stopping after the current video on purpose is not.
+// this function assumes the caller holds the rowMu Rlock.
+func (d *datatable) stoppedMsg(mode playMode) tea.Msg {
+	if mode == playModeStopAfterCurrent {
+		return nil
+	}
+
+	if slices.ContainsFunc(d.rows, func(r row) bool { return r[colWatched] == isWatchedNo }) {
+		return nil
+	}
+
+	return queueDrainedMsg{}
+}
+
 func (d *datatable) playNextOrStopCmd() tea.Cmd {
 	return func() tea.Msg {
 		idx, err := d.setVideoWatched(d.player.getCurrentlyPlayingId())
@@ -186,28 +518,120 @@ func (d *datatable) playNextOrStopCmd() tea.Cmd {
 			return errorMsg{fmt.Errorf("failed to set video as watched: %w", err)}
 		}
 
-		if idx <= 0 {
-			return nil
+		if d.player.consumeSleepFinish() {
+			return d.player.expireSleepTimer()
 		}
 
 		d.rowMu.RLock()
 		defer d.rowMu.RUnlock()
 
-		for i := idx - 1; i >= 0; i-- {
-			row := d.rows[i]
-			if row[colWatched] == isWatchedNo {
-				slog.Debug(
-					"playing next unwatched video",
-					slog.String("id", row[colID]),
-					slog.String("name", row[colName]),
-				)
+		mode := d.player.getPlayMode()
+
+		// up next plays also when the playing video is not shown
+		if mode != playModeStopAfterCurrent {
+			if id, ok := d.popUpNext(); ok {
+				slog.Debug("playing up next video", slog.String("id", id))
 				d.player.setPlaying(playingStatusStopped)
 
-				return d.playStopRowCmd(row[colID])()
+				return d.playRow(id, 0)
 			}
 		}
 
-		return nil
+		next := -1
+		if idx >= 0 {
+			next = d.nextRowIndex(idx, mode)
+		}
+
+		if next < 0 {
+			return d.stoppedMsg(mode)
+		}
+
+		row := d.rows[next]
+		slog.Debug(
+			"playing next video",
+			slog.String("mode", mode.String()),
+			slog.String("id", row[colID]),
+			slog.String("name", row[colName]),
+		)
+		d.player.setPlaying(playingStatusStopped)
+
+		return d.playStopRowCmd(row[colID])()
+	}
+}
+
+// previousRowIndex is the row played before the row at idx in the play order,
+// watched rows are included as going back usually means replaying one.
+// this function assumes the caller holds the rowMu Rlock.
+func (d *datatable) previousRowIndex(idx int, mode playMode) int {
+	prev := idx + 1
+	if mode == playModeSequentialDown {
+		prev = idx - 1
+	}
+
+	if prev < 0 || prev >= len(d.rows) {
+		return -1
+	}
+
+	return prev
+}
+
+// skipVideoCmd plays the next or previous video without marking the playing
+// one as watched.
+func (d *datatable) skipVideoCmd(previous bool) tea.Cmd {
+	return func() tea.Msg {
+		d.rowMu.RLock()
+		defer d.rowMu.RUnlock()
+
+		if !previous {
+			if id, ok := d.popUpNext(); ok {
+				d.player.setPlaying(playingStatusStopped)
+
+				return d.playRow(id, 0)
+			}
+		}
+
+		idx := slices.IndexFunc(d.rows, playingIDIndexFunc(d.player.getCurrentlyPlayingId()))
+		if idx < 0 {
+			return nil
+		}
+
+		mode := d.player.getPlayMode()
+		if mode == playModeRepeatOne || mode == playModeStopAfterCurrent {
+			mode = playModeSequentialUp
+		}
+
+		next := d.previousRowIndex(idx, mode)
+		if !previous {
+			next = d.nextRowIndex(idx, mode)
+		}
+
+		if next < 0 {
+			return nil
+		}
+
+		d.player.setPlaying(playingStatusStopped)
+
+		return d.playRow(d.rows[next][colID], 0)
+	}
+}
+
+func (d *datatable) addUpNextCmd(cursor int) tea.Cmd {
+	return func() tea.Msg {
+		items := make([]upNextItem, 0)
+
+		for _, row := range d.targetRows(cursor) {
+			items = append(items, upNextItem{id: row[colID], name: row[colName]})
+		}
+
+		d.upNext.push(items...)
+
+		const msgDelay = 2 * time.Second
+
+		if len(items) == 1 {
+			return footerMsgCmd("Added to up next: "+items[0].name, msgDelay)()
+		}
+
+		return footerMsgCmd(fmt.Sprintf("Added %d videos to up next", len(items)), msgDelay)()
 	}
 }
 
@@ -229,13 +653,16 @@ func (d *datatable) toggleSelectModeCmd() tea.Cmd {
 func (d *datatable) toggleWatchedStatusCmd(cursor int) tea.Cmd {
 	return func() tea.Msg {
 		rows := d.getCopyOfRows()
+		if cursor >= len(rows) {
+			return nil
+		}
 
 		video, err := d.datastore.toggleWatched(d.getCtx(), rows[cursor][colID])
 		if err != nil {
 			return errorMsg{err}
 		}
 
-		rows[cursor] = videoToRow(*video)
+		rows[cursor] = updatedRow(rows[cursor], *video)
 		d.setRows(rows)
 
 		return nil
@@ -250,30 +677,31 @@ func (d *datatable) updateRowOrderCmd(id string) tea.Cmd {
 		slog.Debug("updating row order", slog.String("id", id))
 
 		idx := slices.IndexFunc(d.rows, func(r row) bool { return r[colID] == id })
-		upperIdx := clamp(idx-1, 0, len(d.rows)-1)
-		lowerIdx := clamp(idx+1, 0, len(d.rows)-1)
+		if idx == -1 {
+			return nil
+		}
 
-		upperOrderUnix, err := strconv.Atoi(d.rows[upperIdx][colOrder])
-		if err != nil {
-			return errorMsg{fmt.Errorf("failed to parse upper order index: %w", err)}
+		var above, below string
+
+		if idx > 0 {
+			above = d.rows[idx-1][colID]
 		}
 
-		lowerOrderUnix, err := strconv.Atoi(d.rows[lowerIdx][colOrder])
-		if err != nil {
-			return errorMsg{fmt.Errorf("failed to parse lower order index: %w", err)}
+		if idx < len(d.rows)-1 {
+			below = d.rows[idx+1][colID]
 		}
 
-		const inHalf = 2
-		newOrderUnix := int64((upperOrderUnix + lowerOrderUnix) / inHalf)
+		playlistID := d.playlist.ID
 
-		if err := d.datastore.updateVideoOrder(d.getCtx(), id, newOrderUnix); err != nil {
+		if err := d.datastore.moveVideo(d.getCtx(), playlistID, id, below, above); err != nil {
 			return errorMsg{fmt.Errorf("failed to update video order: %w", err)}
 		}
 
 		slog.Debug(
 			"updated row order",
 			slog.String("id", id),
-			slog.Int64("new_order_unix", newOrderUnix),
+			slog.String("below", below),
+			slog.String("above", above),
 		)
 
 		return updatedRowOrderMsg{}
@@ -283,23 +711,24 @@ func (d *datatable) updateRowOrderCmd(id string) tea.Cmd {
 func (d *datatable) deleteRowCmd(cursor int) tea.Cmd {
 	return func() tea.Msg {
 		rows := d.getCopyOfRows()
+		if cursor >= len(rows) {
+			return nil
+		}
+
 		row := rows[cursor]
 		rows = append(rows[:cursor], rows[cursor+1:]...)
 		msg := deletedRowMsg{filename: row[colName]}
 
-		fname := filepath.Clean(filepath.Join(row[colLocation], row[colName]))
-		if err := os.Remove(fname); err != nil {
-			if errors.Is(err, os.ErrNotExist) {
-				msg.notFound = true
-			} else {
-				return errorMsg{fmt.Errorf("failed to delete video file: %w", err)}
-			}
+		file, err := trashRowFile(row)
+		if err != nil {
+			return errorMsg{err}
 		}
 
-		if err := d.datastore.deleteVideo(d.getCtx(), row[colID]); err != nil {
+		if err := d.trashVideos([]trashedFile{file}); err != nil {
 			return errorMsg{err}
 		}
 
+		msg.notFound = file.path == ""
 		d.setRows(rows)
 
 		d.cursorMu.Lock()
@@ -320,9 +749,10 @@ func (d *datatable) deleteSelectedRowsCmd() tea.Cmd {
 
 		rows := d.getCopyOfRows()
 		deletedFilenames := make([]string, 0)
-		deleteFailedFiles := make([]string, 0)
+		failedFiles := make([]string, 0)
 		remainingRows := make([]row, 0)
 		dbErrors := make([]error, 0)
+		trashedFiles := make([]trashedFile, 0)
 
 		for idx, row := range rows {
 			if !d.isSelected(idx) {
@@ -330,29 +760,22 @@ func (d *datatable) deleteSelectedRowsCmd() tea.Cmd {
 				continue
 			}
 
-			deleted := true
+			file, err := trashRowFile(row)
+			if err != nil {
+				failedFiles = append(failedFiles, row[colName])
+				remainingRows = append(remainingRows, row)
 
-			fname := filepath.Clean(filepath.Join(row[colLocation], row[colName]))
-			if err := os.Remove(fname); err != nil {
-				if !errors.Is(err, os.ErrNotExist) {
-					deleteFailedFiles = append(deleteFailedFiles, row[colName])
-					deleted = false
-				}
+				continue
 			}
 
-			if err := d.datastore.deleteVideo(d.getCtx(), row[colID]); err != nil {
-				dbErrors = append(
-					dbErrors,
-					fmt.Errorf("failed to delete video %s from datastore: %w", row[colName], err),
-				)
-				deleted = false
-			}
+			deletedFilenames = append(deletedFilenames, row[colName])
+			trashedFiles = append(trashedFiles, file)
+		}
 
-			if !deleted {
-				remainingRows = append(remainingRows, row)
-			} else {
-				deletedFilenames = append(deletedFilenames, row[colName])
-			}
+		// the videos are trashed together so they are undone together
+		if err := d.trashVideos(trashedFiles); err != nil {
+			dbErrors = append(dbErrors, err)
+			deletedFilenames, remainingRows = deletedFilenames[:0], rows
 		}
 
 		d.setRows(remainingRows)
@@ -365,7 +788,7 @@ func (d *datatable) deleteSelectedRowsCmd() tea.Cmd {
 
 		return deletedMultipleRowsMsg{
 			filenames: deletedFilenames,
-			notFounds: deleteFailedFiles,
+			failed:    failedFiles,
 			dbErrors:  dbErrors,
 		}
 	}
@@ -50,6 +50,10 @@ func (d *datatable) keyMsgHandler(msg tea.KeyMsg) tea.Cmd {
 		return cmd
 	}
 
+	if d.searchInput.Focused() {
+		return d.searchKeyMsgHandler(msg)
+	}
+
 	const nameScrollAmount = 5
 
 	switch {
@@ -58,6 +62,8 @@ func (d *datatable) keyMsgHandler(msg tea.KeyMsg) tea.Cmd {
 		d.selectModeMu.Lock()
 		d.selectMode = false
 		d.selectModeMu.Unlock()
+
+		cmd = d.clearSearch()
 	case key.Matches(msg, d.keymap.nameScrollLeft):
 		d.nameScrollLeft(nameScrollAmount)
 	case key.Matches(msg, d.keymap.nameScrollRight):
@@ -66,6 +72,62 @@ func (d *datatable) keyMsgHandler(msg tea.KeyMsg) tea.Cmd {
 		d.cursor2middle()
 	case key.Matches(msg, d.keymap.playOrStop):
 		cmd = d.playStopRowCmd(d.getIDAtIndex(d.cursor))
+	case key.Matches(msg, d.keymap.cyclePlayMode):
+		cmd = d.cyclePlayModeCmd()
+	case key.Matches(msg, d.keymap.addUpNext):
+		cmd = d.addUpNextCmd(d.cursor)
+	case key.Matches(msg, d.keymap.prevChapter):
+		cmd = d.player.seekChapterCmd(-1)
+	case key.Matches(msg, d.keymap.nextChapter):
+		cmd = d.player.seekChapterCmd(1)
+	case key.Matches(msg, d.keymap.chapters):
+		cmd = d.player.chapterPopupCmd()
+	case key.Matches(msg, d.keymap.undoSkip):
+		cmd = d.player.undoSkipCmd()
+	case key.Matches(msg, d.keymap.channelRule):
+		cmd = d.channelRulePopupCmd(d.cursor)
+	case key.Matches(msg, d.keymap.addBookmark):
+		cmd = d.addBookmarkCmd()
+	case key.Matches(msg, d.keymap.bookmarks):
+		cmd = d.bookmarksPopupCmd(d.cursor)
+	case key.Matches(msg, d.keymap.tracks):
+		cmd = d.player.tracksPopupCmd()
+	case key.Matches(msg, d.keymap.toggleSubtitles):
+		cmd = d.player.toggleSubtitlesCmd()
+	case key.Matches(msg, d.keymap.searchSubtitles):
+		cmd = d.subtitleSearchPopupCmd()
+	case key.Matches(msg, d.keymap.screenshot):
+		cmd = d.player.screenshotCmd()
+	case key.Matches(msg, d.keymap.markClip):
+		cmd = d.player.markClipCmd()
+	case key.Matches(msg, d.keymap.exportClip):
+		cmd = d.exportClipCmd()
+	case key.Matches(msg, d.keymap.editTags):
+		cmd = d.editTagsPopupCmd(d.cursor)
+	case key.Matches(msg, d.keymap.tagFilter):
+		cmd = d.tagFilterPopupCmd()
+	case key.Matches(msg, d.keymap.playlists):
+		cmd = d.playlistSwitcherCmd()
+	case key.Matches(msg, d.keymap.addToPlaylist):
+		cmd = d.addToPlaylistPopupCmd(d.cursor)
+	case key.Matches(msg, d.keymap.removeFromPlaylist):
+		cmd = d.removeFromPlaylistCmd(d.cursor)
+	case key.Matches(msg, d.keymap.search):
+		cmd = d.startSearch()
+	case key.Matches(msg, d.keymap.nextMatch):
+		cmd = d.nextMatch(1)
+	case key.Matches(msg, d.keymap.prevMatch):
+		cmd = d.nextMatch(-1)
+	case key.Matches(msg, d.keymap.searchFilter):
+		cmd = d.toggleSearchFilterCmd()
+	case key.Matches(msg, d.keymap.sortColumn):
+		cmd = d.cycleSortCmd()
+	case key.Matches(msg, d.keymap.sortDirection):
+		cmd = d.toggleSortDirectionCmd()
+	case key.Matches(msg, d.keymap.sleepTimer):
+		cmd = d.sleepTimerPopupCmd()
+	case key.Matches(msg, d.keymap.restartPlayer):
+		cmd = d.restartPlayerCmd()
 	case key.Matches(msg, d.keymap.toggleWatched):
 		cmd = d.toggleWatchedStatusCmd(d.cursor)
 	case key.Matches(msg, d.keymap.deleteRow):
@@ -80,12 +142,28 @@ func (d *datatable) keyMsgHandler(msg tea.KeyMsg) tea.Cmd {
 		}
 
 		d.deleteConfirm = !d.deleteConfirm
+	case key.Matches(msg, d.keymap.undo):
+		cmd = d.undoCmd()
+	case key.Matches(msg, d.keymap.redo):
+		cmd = d.redoCmd()
+	case key.Matches(msg, d.keymap.trash):
+		cmd = d.trashPopupCmd()
+	case key.Matches(msg, d.keymap.scanLibrary):
+		cmd = d.scanLibraryCmd(scanReview)
+	case key.Matches(msg, d.keymap.redownload):
+		cmd = d.redownloadPopupCmd(d.cursor)
+	case key.Matches(msg, d.keymap.keep):
+		cmd = d.keepRowCmd(d.cursor)
+	case key.Matches(msg, d.keymap.retentionPreview):
+		cmd = d.retentionPreviewCmd()
 	case key.Matches(msg, d.keymap.refresh):
 		cmd = d.refreshRowsCmd()
 	case key.Matches(msg, d.keymap.selectMode):
 		cmd = d.toggleSelectModeCmd()
 	case key.Matches(msg, d.keymap.copyURL):
 		cmd = d.copyURLCmd(d.cursor)
+	case key.Matches(msg, d.keymap.copyTimestampedURL):
+		cmd = d.copyTimestampedURLCmd()
 	case key.Matches(msg, d.keymap.pasteURL):
 		cmd = d.pasteURLCmd()
 	default:
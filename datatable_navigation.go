@@ -126,6 +126,19 @@ func (d *datatable) cursor2middle() {
 func (d *datatable) moveRow(n int) tea.Cmd {
 	const moveRowDebounceDuration = time.Millisecond * 300
 
+	// neighbours hidden by a filter would make the new position ambiguous
+	if d.getTagFilter().active() {
+		return footerMsgCmd("Clear the tag filter to move videos", 0)
+	}
+
+	if search := d.getSearch(); search.filterOnly && search.active() {
+		return footerMsgCmd("Show all videos with \\ to move videos", 0)
+	}
+
+	if d.getSort().active() {
+		return footerMsgCmd("Switch back to manual order with s to move videos", 0)
+	}
+
 	d.rowMu.Lock()
 	defer d.rowMu.Unlock()
 
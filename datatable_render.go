@@ -1,6 +1,8 @@
 package main
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -10,9 +12,17 @@ import (
 func (d *datatable) renderHeader() string {
 	var s strings.Builder
 
-	for _, col := range d.columns {
-		style := lipgloss.NewStyle().Width(d.widths[col]).Padding(0, dtCellPadding)
-		s.WriteString(style.Render(string(col)))
+	sort := d.getSort()
+	columns := d.shownColumns(sort)
+
+	for _, col := range columns {
+		label := string(col)
+		if sort.active() && col == sort.key.column() {
+			label += " " + sort.arrow()
+		}
+
+		style := lipgloss.NewStyle().Width(d.colWidth(col, columns)).Padding(0, dtCellPadding)
+		s.WriteString(style.Render(label))
 	}
 
 	return lipgloss.NewStyle().
@@ -21,7 +31,7 @@ func (d *datatable) renderHeader() string {
 }
 
 // this function assumes the caller holds the rowMu Rlock.
-func (d *datatable) renderRow(r int) string {
+func (d *datatable) renderRow(r int, columns []column) string {
 	d.cursorMu.RLock()
 	defer d.cursorMu.RUnlock()
 
@@ -33,10 +43,11 @@ func (d *datatable) renderRow(r int) string {
 			Align(lipgloss.Center).
 			Foreground(lipgloss.Color("0")).
 			Background(lipgloss.Color("9")).
-			Render("Delete this row? (press 'x' again to confirm, 'esc' to cancel)")
+			Render("Move this row to trash? (press 'x' again to confirm, 'esc' to cancel)")
 	}
 
 	isPlaying := d.player.getCurrentlyPlayingId() == d.rows[r][colID]
+	match := d.getSearch().matches[d.rows[r][colID]]
 	rowStyle := lipgloss.NewStyle()
 
 	if r == d.cursor || d.isSelected(r) {
@@ -47,9 +58,10 @@ func (d *datatable) renderRow(r int) string {
 		}
 	}
 
-	for _, colKey := range d.columns {
-		style := lipgloss.NewStyle().Width(d.widths[colKey]).Padding(0, dtCellPadding)
-		cellWidth := d.widths[colKey] - style.GetHorizontalFrameSize()
+	for _, colKey := range columns {
+		width := d.colWidth(colKey, columns)
+		style := lipgloss.NewStyle().Width(width).Padding(0, dtCellPadding)
+		cellWidth := width - style.GetHorizontalFrameSize()
 		colValue := d.rows[r][colKey]
 
 		switch colKey {
@@ -60,11 +72,37 @@ func (d *datatable) renderRow(r int) string {
 		case colWatched:
 			style = style.AlignHorizontal(lipgloss.Center)
 
+			if d.rows[r][colKeep] == isWatchedYes {
+				colValue += keepMarker
+			}
+
 			if isPlaying {
 				rowStyle = rowStyle.Bold(true).Background(lipgloss.Color("34"))
 			}
 		case colLocation:
 			colValue = shortenPath(colValue)
+		case colDuration:
+			if colValue != "" {
+				colValue = formatPlaytime(parseSeconds(colValue))
+			}
+		case colSize:
+			if size, err := strconv.ParseUint(colValue, 10, 64); err == nil {
+				colValue = formatBytes(size)
+			}
+		}
+
+		// a name scrolled left no longer lines up with the matched offsets
+		if matched := match[colKey]; len(matched) > 0 && colValue == searchText(d.rows[r], colKey) {
+			colValue = highlightMatches(
+				colValue,
+				matched,
+				cellWidth,
+				rowStyle,
+				d.matchStyle.Inherit(rowStyle),
+			)
+			s.WriteString(rowStyle.Render(style.Inherit(rowStyle).Render(colValue)))
+
+			continue
 		}
 
 		colValue = runewidth.Truncate(colValue, cellWidth, "…")
@@ -74,9 +112,48 @@ func (d *datatable) renderRow(r int) string {
 	return s.String()
 }
 
+// renderViewBar shows the playlist and the tag filter narrowing the table, it
+// is empty while all videos are shown.
+func (d *datatable) renderViewBar() string {
+	d.rowMu.RLock()
+	playlist, filter, count := d.playlist, d.filter, len(d.rows)
+	d.rowMu.RUnlock()
+
+	parts := make([]string, 0)
+
+	if playlist.ID != 0 {
+		parts = append(parts, fmt.Sprintf("Playlist: %s (P to switch)", playlist.Name))
+	}
+
+	if filter.active() {
+		parts = append(parts, fmt.Sprintf("Filter: %s (f to change)", filter.expr))
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+
+	parts = append(parts, fmt.Sprintf("%d videos", count))
+
+	return lipgloss.NewStyle().
+		Width(d.width).
+		Padding(0, dtCellPadding).
+		Foreground(lipgloss.Color("214")).
+		Render(strings.Join(parts, "  "))
+}
+
 func (d *datatable) View() string {
 	playingRow := ""
 	header := d.renderHeader()
+
+	if searchBar := d.renderSearchBar(); searchBar != "" {
+		header = lipgloss.JoinVertical(lipgloss.Left, searchBar, header)
+	}
+
+	if viewBar := d.renderViewBar(); viewBar != "" {
+		header = lipgloss.JoinVertical(lipgloss.Left, viewBar, header)
+	}
+
 	d.viewport.Height = d.styles.GetHeight() - lipgloss.Height(header)
 	content := lipgloss.JoinVertical(lipgloss.Top, header, d.viewport.View())
 
@@ -1,16 +1,31 @@
 package main
 
-import tea "github.com/charmbracelet/bubbletea"
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
 
 type downloadQueuedMsg struct {
 	url string
 }
 
-func enqueueURLCmd(d *downloader, url string) tea.Cmd {
+// downloadRequest is a queued download, start is the playback position taken
+// from the submitted URL.
+type downloadRequest struct {
+	url   string
+	start time.Duration
+	// videoID is the video whose file is downloaded again, zero for a new one
+	videoID int64
+	// format is the yt-dlp format selector, empty for the default
+	format string
+}
+
+func enqueueCmd(d *downloader, req downloadRequest) tea.Cmd {
 	return func() tea.Msg {
-		d.enqueue(url)
+		d.enqueue(req)
 
-		return downloadQueuedMsg{url}
+		return downloadQueuedMsg{req.url}
 	}
 }
 
@@ -22,12 +37,40 @@ type finishDownloadMsg struct {
 	filename     string
 	downloadPath string
 	url          string
+	start        time.Duration
+	videoID      int64
+	channel      string
+	uploadDate   string // YYYYMMDD
+	duration     float64
+	segments     []sponsorSegment
+	subtitles    []downloadedSubtitle
+}
+
+type sponsorSegment struct {
+	Category  string  `json:"category"`
+	StartTime float64 `json:"start_time"`
+	EndTime   float64 `json:"end_time"`
+}
+
+type subtitleFile struct {
+	Ext string `json:"ext"`
+}
+
+// downloadedSubtitle is a subtitle language of a downloaded video, path is
+// empty when the subtitle was embedded into the video file.
+type downloadedSubtitle struct {
+	language string
+	path     string
 }
 
 type downloadErrorMsg struct {
 	msg string
 }
 
+type downloadFailedMsg struct {
+	url string
+}
+
 type downloadCompletedMsg struct {
 	url string
 }
@@ -41,6 +84,12 @@ type downloadProgressMsg struct {
 	Speed           float64 `json:"speed"`
 	Elapsed         float64 `json:"elapsed"`
 	Eta             float64 `json:"eta"`
+	Channel         string  `json:"channel"`
+	UploadDate      string  `json:"upload_date"`
+	Duration        float64 `json:"duration"`
+
+	SponsorBlock []sponsorSegment        `json:"sponsorblock"`
+	Subtitles    map[string]subtitleFile `json:"subtitles"`
 }
 
 type downloadStatus int
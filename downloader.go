@@ -21,13 +21,17 @@ type downloader struct {
 	tempDir          string
 	browserCookies   string
 	browserUserAgent string
-	queue            chan string
+	sponsorBlock     bool
+	subLanguages     []string
+	autoSubs         bool
+	embedSubs        bool
+	queue            chan downloadRequest
 	wg               *sync.WaitGroup
 }
 
 func newDownloader(cfg *config) *downloader {
 	const queueSize = 100
-	q := make(chan string, queueSize)
+	q := make(chan downloadRequest, queueSize)
 	wg := new(sync.WaitGroup)
 
 	return &downloader{
@@ -36,6 +40,10 @@ func newDownloader(cfg *config) *downloader {
 		tempDir:          cfg.tempDir,
 		browserCookies:   cfg.BrowserCookies,
 		browserUserAgent: cfg.UserAgent,
+		sponsorBlock:     cfg.SponsorBlock,
+		subLanguages:     cfg.SubLanguages,
+		autoSubs:         cfg.AutoSubs,
+		embedSubs:        cfg.EmbedSubs,
 		queue:            q,
 		wg:               wg,
 	}
@@ -48,9 +56,13 @@ func (d *downloader) setProgram(p *tea.Program) {
 const (
 	titleFormat            = "%(title).50s [%(id)s].%(ext)s"
 	progressUpdateInterval = time.Millisecond * 100
+	afterMoveTemplate      = `after_move:{"status": "after_move", "filename": "%(filepath)s", ` +
+		`"channel": %(channel|null)j, "sponsorblock": %(sponsorblock_chapters|[])j, ` +
+		`"subtitles": %(requested_subtitles|{})j, "upload_date": %(upload_date|null)j, ` +
+		`"duration": %(duration|null)j}`
 )
 
-func (d *downloader) readStdout(stdoutPipe io.ReadCloser, url string) {
+func (d *downloader) readStdout(stdoutPipe io.ReadCloser, req downloadRequest) {
 	scanner := bufio.NewScanner(stdoutPipe)
 	ticker := time.NewTicker(progressUpdateInterval)
 
@@ -74,7 +86,14 @@ func (d *downloader) readStdout(stdoutPipe io.ReadCloser, url string) {
 			d.p.Send(finishDownloadMsg{
 				filename:     filepath.Base(msg.Filename),
 				downloadPath: d.downloadDir,
-				url:          url,
+				url:          req.url,
+				start:        req.start,
+				videoID:      req.videoID,
+				channel:      msg.Channel,
+				uploadDate:   msg.UploadDate,
+				duration:     msg.Duration,
+				segments:     msg.SponsorBlock,
+				subtitles:    d.downloadedSubtitles(msg.Filename, msg.Subtitles),
 			})
 		case "error":
 			slog.Error("download error", slog.String("stdout", scanner.Text()))
@@ -93,7 +112,7 @@ func (d *downloader) readStderr(stderrPipe io.ReadCloser) {
 	}
 }
 
-func (d *downloader) download(ctx context.Context, url string, secondTry ...bool) {
+func (d *downloader) download(ctx context.Context, req downloadRequest, secondTry ...bool) {
 	const concurrentFragments = "100"
 
 	args := make([]string, 0)
@@ -101,7 +120,7 @@ func (d *downloader) download(ctx context.Context, url string, secondTry ...bool
 		"--concurrent-fragments",
 		concurrentFragments,
 		"--print",
-		`after_move:{"status": "after_move", "filename": "%(filepath)s"}`,
+		afterMoveTemplate,
 		"--progress",
 		"--progress-template",
 		"%(progress)j",
@@ -124,11 +143,26 @@ func (d *downloader) download(ctx context.Context, url string, secondTry ...bool
 		args = append(args, "--cookies-from-browser", d.browserCookies)
 	}
 
+	if d.sponsorBlock {
+		args = append(args, "--sponsorblock-mark", "all")
+	}
+
+	args = append(args, d.subtitleArgs()...)
+
+	if req.format != "" {
+		args = append(args, "--format", req.format)
+	}
+
+	// a file downloaded again replaces the one of the same name
+	if req.videoID != 0 {
+		args = append(args, "--force-overwrites")
+	}
+
 	if len(secondTry) > 0 && secondTry[0] {
 		args = append(args, "--impersonate", "chrome")
 	}
 
-	args = append(args, url)
+	args = append(args, req.url)
 	cmd := exec.CommandContext(ctx, "yt-dlp", args...) // #nosec G204
 
 	slog.Debug("executing download command", slog.String("command", cmd.String()))
@@ -156,41 +190,47 @@ func (d *downloader) download(ctx context.Context, url string, secondTry ...bool
 		return
 	}
 
-	go d.readStdout(stdoutPipe, url)
+	go d.readStdout(stdoutPipe, req)
 	go d.readStderr(stderrPipe)
 
 	if err := cmd.Wait(); err != nil {
 		if len(secondTry) == 0 {
-			d.download(ctx, url, true)
+			d.download(ctx, req, true)
+
+			return
+		}
+
+		if ctx.Err() == nil {
+			d.p.Send(downloadFailedMsg{req.url})
 		}
 
 		return
 	}
 }
 
-func (d *downloader) enqueue(url string) {
-	if url == "" {
+func (d *downloader) enqueue(req downloadRequest) {
+	if req.url == "" {
 		return
 	}
 
-	d.queue <- url
+	d.queue <- req
 }
 
-func (d *downloader) startDownload(ctx context.Context, url string) {
+func (d *downloader) startDownload(ctx context.Context, req downloadRequest) {
 	d.wg.Add(1)
 	defer d.wg.Done()
 
-	slog.Info("starting download", slog.String("url", url))
+	slog.Info("starting download", slog.String("url", req.url))
 
 	if d.p == nil {
 		slog.Error("program pointer is nil, cannot send finish download message")
 		return
 	}
 
-	d.p.Send(startDownloadMsg{url})
-	d.download(ctx, url)
-	d.p.Send(downloadCompletedMsg{url})
-	slog.Info("download completed", slog.String("url", url))
+	d.p.Send(startDownloadMsg{req.url})
+	d.download(ctx, req)
+	d.p.Send(downloadCompletedMsg{req.url})
+	slog.Info("download completed", slog.String("url", req.url))
 }
 
 func (d *downloader) start(ctx context.Context) {
@@ -198,8 +238,8 @@ func (d *downloader) start(ctx context.Context) {
 		select {
 		case <-ctx.Done():
 			return
-		case url := <-d.queue:
-			d.startDownload(ctx, url)
+		case req := <-d.queue:
+			d.startDownload(ctx, req)
 		}
 	}
 }
@@ -0,0 +1,479 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/linnovs/ytqueue/database"
+)
+
+// The history keeps the changes made to the library so they can be undone
+// and redone, also after a restart. Changing something after undoing drops
+// the undone changes. Deleting videos for good and downloading a file again
+// are left out, the files they delete cannot come back.
+const defaultHistoryDepth = 100
+
+type opKind string
+
+const (
+	opTrash          opKind = "trash"
+	opRestore        opKind = "restore"
+	opWatched        opKind = "watched"
+	opMove           opKind = "move"
+	opTags           opKind = "tags"
+	opPlaylistAdd    opKind = "playlist_add"
+	opPlaylistRemove opKind = "playlist_remove"
+	opKeep           opKind = "keep"
+	opChannel        opKind = "channel"
+	opChannelRule    opKind = "channel_rule"
+	opRelink         opKind = "relink"
+	opMoveFiles      opKind = "move_files"
+)
+
+// operation is a change of the library kept in the history, the state of
+// each video before and after lets it be applied both ways.
+type operation struct {
+	Kind       opKind    `json:"kind"`
+	PlaylistID int64     `json:"playlistId,omitempty"`
+	Videos     []opVideo `json:"videos"`
+	Rule       *opRule   `json:"rule,omitempty"`
+}
+
+// opRule is the playback rule of a channel before and after a change, nil
+// when the channel has none.
+type opRule struct {
+	Channel string                `json:"channel"`
+	Before  *database.ChannelRule `json:"before,omitempty"`
+	After   *database.ChannelRule `json:"after,omitempty"`
+}
+
+type opVideo struct {
+	ID     int64   `json:"id"`
+	Before opState `json:"before"`
+	After  opState `json:"after"`
+}
+
+// opState is the part of a video an operation changes.
+type opState struct {
+	Watched bool `json:"watched,omitempty"`
+	// WatchedAt is kept so undoing does not restart the retention age.
+	WatchedAt *time.Time `json:"watchedAt,omitempty"`
+	Tags      []string   `json:"tags,omitempty"`
+	Keep      bool       `json:"keep,omitempty"`
+	Channel   *string    `json:"channel,omitempty"`
+	// Path is the file the video points to.
+	Path string `json:"path,omitempty"`
+	// Below and Above are the neighbours of a video moved or taken out of a
+	// playlist, zero is the end of the list.
+	Below int64 `json:"below,omitempty"`
+	Above int64 `json:"above,omitempty"`
+}
+
+type historyStepMsg struct {
+	description string
+	undo        bool
+}
+
+func (o operation) videoIDs() []int64 {
+	ids := make([]int64, 0, len(o.Videos))
+	for _, v := range o.Videos {
+		ids = append(ids, v.ID)
+	}
+
+	return ids
+}
+
+// describeVideos names the video for a history entry, or counts them.
+func describeVideos(ctx context.Context, q *database.Queries, ids []int64) string {
+	if len(ids) != 1 {
+		return fmt.Sprintf("%d videos", len(ids))
+	}
+
+	video, err := q.GetVideo(ctx, ids[0])
+	if err != nil {
+		return "1 video"
+	}
+
+	return video.Name
+}
+
+// record adds op to the history, the changes undone before are dropped and
+// the oldest ones past the depth are forgotten. Nothing is kept without a
+// depth.
+func (s *datastore) record(
+	ctx context.Context,
+	q *database.Queries,
+	description string,
+	op operation,
+) error {
+	if s.historyDepth <= 0 || (len(op.Videos) == 0 && op.Rule == nil) {
+		return nil
+	}
+
+	data, err := json.Marshal(op)
+	if err != nil {
+		return err
+	}
+
+	if err := q.DeleteUndoneHistory(ctx); err != nil {
+		return err
+	}
+
+	if err := q.AddHistoryEntry(ctx, database.AddHistoryEntryParams{
+		Description: description,
+		Operation:   string(data),
+	}); err != nil {
+		return err
+	}
+
+	return q.TrimHistory(ctx, int64(s.historyDepth))
+}
+
+// undo reverts the latest change not undone yet, the description is empty
+// when there is nothing to undo.
+func (s *datastore) undo(ctx context.Context) (string, error) {
+	return s.stepHistory(ctx, true)
+}
+
+// redo applies the earliest change undone again.
+func (s *datastore) redo(ctx context.Context) (string, error) {
+	return s.stepHistory(ctx, false)
+}
+
+func (s *datastore) stepHistory(ctx context.Context, undo bool) (string, error) {
+	getEntry := s.queries.GetFirstUndoneHistoryEntry
+	if undo {
+		getEntry = s.queries.GetLastDoneHistoryEntry
+	}
+
+	entry, err := getEntry(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	var op operation
+	if err := json.Unmarshal([]byte(entry.Operation), &op); err != nil {
+		return "", fmt.Errorf("invalid history entry %d: %w", entry.ID, err)
+	}
+
+	mark := func(q *database.Queries) error {
+		return q.SetHistoryEntryUndone(ctx, database.SetHistoryEntryUndoneParams{
+			Undone: undo,
+			ID:     entry.ID,
+		})
+	}
+
+	// files moved in and out of the trash are not rolled back with the
+	// database, so each video is done on its own
+	if op.Kind == opTrash || op.Kind == opRestore {
+		if err := s.applyTrash(ctx, op.videoIDs(), (op.Kind == opTrash) != undo); err != nil {
+			return "", err
+		}
+
+		return entry.Description, mark(s.queries)
+	}
+
+	if op.Kind == opMoveFiles {
+		if err := s.applyFileMoves(ctx, op, undo); err != nil {
+			return "", err
+		}
+
+		return entry.Description, mark(s.queries)
+	}
+
+	return entry.Description, s.inTx(ctx, func(q *database.Queries) error {
+		if err := applyOperation(ctx, q, op, undo); err != nil {
+			return err
+		}
+
+		return mark(q)
+	})
+}
+
+// applyOperation changes the videos to their state after op, or before it
+// when undoing. Videos deleted since are skipped.
+func applyOperation(ctx context.Context, q *database.Queries, op operation, undo bool) error {
+	videos := slices.Clone(op.Videos)
+	if undo {
+		// videos taken out of a playlist go back in the order they left
+		slices.Reverse(videos)
+	}
+
+	if op.PlaylistID != 0 {
+		if _, err := q.GetPlaylist(ctx, op.PlaylistID); errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+	}
+
+	if op.Rule != nil {
+		rule := op.Rule.After
+		if undo {
+			rule = op.Rule.Before
+		}
+
+		if err := writeChannelRule(ctx, q, op.Rule.Channel, rule); err != nil {
+			return err
+		}
+	}
+
+	for _, v := range videos {
+		if _, err := q.GetVideo(ctx, v.ID); errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+
+		from, to := v.Before, v.After
+		if undo {
+			from, to = to, from
+		}
+
+		if err := applyVideoState(ctx, q, op, v.ID, from, to, undo); err != nil {
+			return err
+		}
+	}
+
+	if op.Kind == opTags {
+		return q.DeleteUnusedTags(ctx)
+	}
+
+	return nil
+}
+
+func applyVideoState(
+	ctx context.Context,
+	q *database.Queries,
+	op operation,
+	id int64,
+	from, to opState,
+	undo bool,
+) error {
+	switch op.Kind {
+	case opWatched:
+		return q.SetVideoWatched(ctx, database.SetVideoWatchedParams{
+			IsWatched: &to.Watched,
+			WatchedAt: to.WatchedAt,
+			ID:        id,
+		})
+	case opKeep:
+		return q.SetVideoKeep(ctx, database.SetVideoKeepParams{Keep: to.Keep, ID: id})
+	case opChannel:
+		_, err := q.SetVideoChannel(ctx, database.SetVideoChannelParams{Channel: to.Channel, ID: id})
+
+		return err
+	case opRelink:
+		return relinkVideo(ctx, q, id, to.Path)
+	case opMove:
+		order := newVideoOrder(q, op.PlaylistID)
+
+		rank, err := neighbourRank(ctx, order, to)
+		if err != nil {
+			return err
+		}
+
+		return order.setRank(ctx, id, rank)
+	case opTags:
+		return changeVideoTags(ctx, q, id, from.Tags, to.Tags)
+	case opPlaylistAdd, opPlaylistRemove:
+		if (op.Kind == opPlaylistAdd) == undo {
+			return q.RemovePlaylistVideo(ctx, database.RemovePlaylistVideoParams{
+				PlaylistID: op.PlaylistID,
+				VideoID:    id,
+			})
+		}
+
+		rank, err := neighbourRank(ctx, newVideoOrder(q, op.PlaylistID), to)
+		if err != nil {
+			return err
+		}
+
+		return q.AddPlaylistVideo(ctx, database.AddPlaylistVideoParams{
+			PlaylistID: op.PlaylistID,
+			VideoID:    id,
+			Rank:       rank,
+		})
+	case opTrash, opRestore, opChannelRule, opMoveFiles:
+	}
+
+	return nil
+}
+
+// neighbourRank returns a rank between the neighbours of state, the top is
+// used when one of them is not in the list anymore or none was kept.
+func neighbourRank(ctx context.Context, order videoOrder, state opState) (string, error) {
+	if state.Below != 0 || state.Above != 0 {
+		rank, err := videoRankBetween(ctx, order, state.Below, state.Above)
+		if !errors.Is(err, sql.ErrNoRows) {
+			return rank, err
+		}
+	}
+
+	top, err := order.topID(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return videoRankBetween(ctx, order, top, 0)
+}
+
+// neighbours returns the neighbours of id in ids ordered by rank.
+func neighbours(ids []int64, id int64) opState {
+	var state opState
+
+	i := slices.Index(ids, id)
+	if i > 0 {
+		state.Below = ids[i-1]
+	}
+
+	if i >= 0 && i < len(ids)-1 {
+		state.Above = ids[i+1]
+	}
+
+	return state
+}
+
+// applyTrash moves the videos into the trash, or restores them, skipping the
+// ones already there or not.
+func (s *datastore) applyTrash(ctx context.Context, ids []int64, trash bool) error {
+	for _, id := range ids {
+		var err error
+
+		if trash {
+			err = s.retrashVideo(ctx, id)
+		} else {
+			_, err = s.restoreTrashedVideo(ctx, id)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyFileMoves moves the files of the videos to where they were after op,
+// or before it when undoing. Videos deleted or pointing to another file since
+// are skipped.
+func (s *datastore) applyFileMoves(ctx context.Context, op operation, undo bool) error {
+	for _, v := range op.Videos {
+		from, to := v.Before.Path, v.After.Path
+		if undo {
+			from, to = to, from
+		}
+
+		video, err := s.queries.GetVideo(ctx, v.ID)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if video.DeletedAt != nil || filepath.Join(video.Location, video.Name) != from {
+			continue
+		}
+
+		if err := moveFile(from, to); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to move %s: %w", video.Name, err)
+		}
+
+		if err := relinkVideo(ctx, s.queries, v.ID, to); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *datastore) retrashVideo(ctx context.Context, id int64) error {
+	video, err := s.queries.GetVideo(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+
+	if err != nil || video.DeletedAt != nil {
+		return err
+	}
+
+	trashPath, err := moveToTrash(filepath.Join(video.Location, video.Name))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w %s: %w", errTrashFile, video.Name, err)
+	}
+
+	params := database.TrashVideoParams{ID: id}
+	if trashPath != "" {
+		params.TrashPath = &trashPath
+	}
+
+	return s.queries.TrashVideo(ctx, params)
+}
+
+// restoreTrashedVideo moves the file of a trashed video back and shows the
+// video again, the name is empty when the video is not in the trash. A file
+// emptied from the trash meanwhile leaves the video without one.
+func (s *datastore) restoreTrashedVideo(ctx context.Context, id int64) (string, error) {
+	video, err := s.queries.GetVideo(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+
+	if err != nil || video.DeletedAt == nil {
+		return "", err
+	}
+
+	if video.TrashPath != nil {
+		err := restoreFromTrash(*video.TrashPath, filepath.Join(video.Location, video.Name))
+		if errors.Is(err, errTrashedFileGone) {
+			slog.Warn("restoring video without its file", slog.String("name", video.Name))
+		} else if err != nil {
+			return "", fmt.Errorf("failed to restore %s: %w", video.Name, err)
+		}
+	}
+
+	if err := s.queries.RestoreVideo(ctx, id); err != nil {
+		return "", err
+	}
+
+	return video.Name, nil
+}
+
+func (d *datatable) undoCmd() tea.Cmd {
+	return d.stepHistoryCmd(true)
+}
+
+func (d *datatable) redoCmd() tea.Cmd {
+	return d.stepHistoryCmd(false)
+}
+
+func (d *datatable) stepHistoryCmd(undo bool) tea.Cmd {
+	return func() tea.Msg {
+		step, action := d.datastore.redo, "redo"
+		if undo {
+			step, action = d.datastore.undo, "undo"
+		}
+
+		description, err := step(d.getCtx())
+		if err != nil {
+			return errorMsg{fmt.Errorf("failed to %s: %w", action, err)}
+		}
+
+		if description == "" {
+			return footerMsgCmd("Nothing to "+action, 0)()
+		}
+
+		return historyStepMsg{description: description, undo: undo}
+	}
+}
@@ -41,13 +41,25 @@ func (p promptKeymap) FullHelp() [][]key.Binding {
 
 type datatableKeymap struct {
 	baseKeymap
-	lineUp, lineDown, moveUp, moveDown              key.Binding
-	pageUp, pageDown, halfPageUp, halfPageDown      key.Binding
-	scrollToTop, scrollToBottom                     key.Binding
-	gotoTop, gotoBottom, gotoPlaying, cursor2middle key.Binding
-	playOrStop, toggleWatched, deleteRow, refresh   key.Binding
-	nameScrollLeft, nameScrollRight                 key.Binding
-	selectMode, copyURL, pasteURL                   key.Binding
+	lineUp, lineDown, moveUp, moveDown                key.Binding
+	pageUp, pageDown, halfPageUp, halfPageDown        key.Binding
+	scrollToTop, scrollToBottom                       key.Binding
+	gotoTop, gotoBottom, gotoPlaying, cursor2middle   key.Binding
+	playOrStop, toggleWatched, deleteRow, refresh     key.Binding
+	undo, redo, trash, scanLibrary, redownload        key.Binding
+	keep, retentionPreview                            key.Binding
+	restartPlayer, cyclePlayMode, sleepTimer          key.Binding
+	addUpNext, prevChapter, nextChapter, chapters     key.Binding
+	undoSkip, channelRule, addBookmark, bookmarks     key.Binding
+	tracks, toggleSubtitles, searchSubtitles          key.Binding
+	screenshot, markClip, exportClip                  key.Binding
+	editTags, tagFilter                               key.Binding
+	playlists, addToPlaylist, removeFromPlaylist      key.Binding
+	search, nextMatch, prevMatch, searchFilter        key.Binding
+	searchDone, searchCancel                          key.Binding
+	sortColumn, sortDirection                         key.Binding
+	nameScrollLeft, nameScrollRight                   key.Binding
+	selectMode, copyURL, copyTimestampedURL, pasteURL key.Binding
 }
 
 func (d datatableKeymap) ShortHelp() []key.Binding {
@@ -61,13 +73,52 @@ func (d datatableKeymap) FullHelp() [][]key.Binding {
 		{d.pageUp, d.pageDown, d.halfPageUp, d.halfPageDown, d.scrollToTop, d.scrollToBottom},
 		{d.gotoTop, d.gotoBottom, d.gotoPlaying, d.cursor2middle, d.copyURL, d.pasteURL},
 		{d.playOrStop, d.toggleWatched, d.deleteRow, d.selectMode, d.refresh},
+		{d.undo, d.redo, d.trash, d.scanLibrary, d.redownload},
+		{d.keep, d.retentionPreview},
+		{d.restartPlayer, d.cyclePlayMode, d.sleepTimer, d.addUpNext},
+		{d.prevChapter, d.nextChapter, d.chapters, d.undoSkip, d.channelRule},
+		{d.addBookmark, d.bookmarks, d.copyTimestampedURL, d.tracks, d.toggleSubtitles},
+		{d.searchSubtitles, d.screenshot, d.markClip, d.exportClip},
+		{d.editTags, d.tagFilter},
+		{d.playlists, d.addToPlaylist, d.removeFromPlaylist},
+		{d.search, d.nextMatch, d.prevMatch, d.searchFilter},
+		{d.sortColumn, d.sortDirection},
 	}
 }
 
+type upNextKeymap struct {
+	baseKeymap
+	lineUp, lineDown, moveUp, moveDown, remove, clear key.Binding
+}
+
+func (u upNextKeymap) ShortHelp() []key.Binding {
+	return []key.Binding{}
+}
+
+func (u upNextKeymap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		u.Help(),
+		{u.lineUp, u.lineDown, u.moveUp, u.moveDown, u.remove, u.clear},
+	}
+}
+
+type popupKeymap struct {
+	lineUp, lineDown, selectItem, close key.Binding
+}
+
+func (p popupKeymap) ShortHelp() []key.Binding {
+	return []key.Binding{p.lineUp, p.lineDown, p.selectItem, p.close}
+}
+
+func (p popupKeymap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{p.ShortHelp()}
+}
+
 type keymap struct {
 	baseKeymap
 	prompt    promptKeymap
 	datatable datatableKeymap
+	upNext    upNextKeymap
 }
 
 func newKeymap() keymap {
@@ -75,6 +126,55 @@ func newKeymap() keymap {
 		baseKeymap: newBaseKeymap(),
 		prompt:     newPromptKeymap(),
 		datatable:  newDatatableKeymap(),
+		upNext:     newUpNextKeymap(),
+	}
+}
+
+func newUpNextKeymap() upNextKeymap {
+	return upNextKeymap{
+		baseKeymap: newBaseKeymap(),
+		lineUp:     key.NewBinding(key.WithKeys("k", "up"), key.WithHelp("↑/k", "move cursor up")),
+		lineDown: key.NewBinding(
+			key.WithKeys("j", "down"),
+			key.WithHelp("↓/j", "move cursor down"),
+		),
+		moveUp:   key.NewBinding(key.WithKeys("K"), key.WithHelp("shift+k", "move item up")),
+		moveDown: key.NewBinding(key.WithKeys("J"), key.WithHelp("shift+j", "move item down")),
+		remove:   key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "remove item")),
+		clear:    key.NewBinding(key.WithKeys("X"), key.WithHelp("X", "clear up next")),
+	}
+}
+
+type formPopupKeymap struct {
+	next, prev, submit, close key.Binding
+}
+
+func (f formPopupKeymap) ShortHelp() []key.Binding {
+	return []key.Binding{f.next, f.prev, f.submit, f.close}
+}
+
+func (f formPopupKeymap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{f.ShortHelp()}
+}
+
+func newFormPopupKeymap() formPopupKeymap {
+	return formPopupKeymap{
+		next: key.NewBinding(key.WithKeys("tab", "down"), key.WithHelp("tab/↓", "next field")),
+		prev: key.NewBinding(
+			key.WithKeys("shift+tab", "up"),
+			key.WithHelp("shift+tab/↑", "previous field"),
+		),
+		submit: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "save")),
+		close:  key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+	}
+}
+
+func newPopupKeymap() popupKeymap {
+	return popupKeymap{
+		lineUp:     key.NewBinding(key.WithKeys("k", "up"), key.WithHelp("↑/k", "up")),
+		lineDown:   key.NewBinding(key.WithKeys("j", "down"), key.WithHelp("↓/j", "down")),
+		selectItem: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "select")),
+		close:      key.NewBinding(key.WithKeys("esc", "q"), key.WithHelp("esc/q", "close")),
 	}
 }
 
@@ -126,8 +226,72 @@ func newDatatableKeymap() datatableKeymap {
 			key.WithKeys(" "),
 			key.WithHelp("space", "toggle watched"),
 		),
-		deleteRow: key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "delete row")),
+		deleteRow: key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "move row to trash")),
 		refresh:   key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "refresh data")),
+		undo:      key.NewBinding(key.WithKeys("u"), key.WithHelp("u", "undo")),
+		redo:      key.NewBinding(key.WithKeys("ctrl+r"), key.WithHelp("ctrl+r", "redo")),
+		trash:     key.NewBinding(key.WithKeys("D"), key.WithHelp("D", "trash")),
+		scanLibrary: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", "scan library for missing and new files"),
+		),
+		redownload: key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "download again")),
+		keep:       key.NewBinding(key.WithKeys("*"), key.WithHelp("*", "keep from retention")),
+		retentionPreview: key.NewBinding(
+			key.WithKeys("H"),
+			key.WithHelp("H", "retention preview"),
+		),
+		restartPlayer: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "restart player"),
+		),
+		cyclePlayMode: key.NewBinding(key.WithKeys("m"), key.WithHelp("m", "cycle play mode")),
+		sleepTimer:    key.NewBinding(key.WithKeys("Z"), key.WithHelp("Z", "sleep timer")),
+		addUpNext:     key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "add to up next")),
+		prevChapter:   key.NewBinding(key.WithKeys("["), key.WithHelp("[", "previous chapter")),
+		nextChapter:   key.NewBinding(key.WithKeys("]"), key.WithHelp("]", "next chapter")),
+		chapters:      key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "chapter list")),
+		undoSkip:      key.NewBinding(key.WithKeys("U"), key.WithHelp("U", "undo segment skip")),
+		channelRule:   key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "channel playback rule")),
+		addBookmark:   key.NewBinding(key.WithKeys("M"), key.WithHelp("M", "bookmark position")),
+		bookmarks:     key.NewBinding(key.WithKeys("'"), key.WithHelp("'", "bookmarks")),
+		tracks:        key.NewBinding(key.WithKeys("T"), key.WithHelp("T", "tracks")),
+		toggleSubtitles: key.NewBinding(
+			key.WithKeys("V"),
+			key.WithHelp("V", "toggle subtitles"),
+		),
+		searchSubtitles: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F", "search subtitles"),
+		),
+		screenshot: key.NewBinding(key.WithKeys("I"), key.WithHelp("I", "screenshot")),
+		markClip:   key.NewBinding(key.WithKeys("A"), key.WithHelp("A", "mark clip A/B")),
+		exportClip: key.NewBinding(key.WithKeys("E"), key.WithHelp("E", "export clip")),
+		editTags:   key.NewBinding(key.WithKeys("#"), key.WithHelp("#", "edit tags")),
+		tagFilter:  key.NewBinding(key.WithKeys("f"), key.WithHelp("f", "filter by tags")),
+		playlists:  key.NewBinding(key.WithKeys("P"), key.WithHelp("P", "switch playlist")),
+		addToPlaylist: key.NewBinding(
+			key.WithKeys("+"),
+			key.WithHelp("+", "add to playlist"),
+		),
+		removeFromPlaylist: key.NewBinding(
+			key.WithKeys("-"),
+			key.WithHelp("-", "remove from playlist"),
+		),
+		search:    key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "search")),
+		nextMatch: key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "next match")),
+		prevMatch: key.NewBinding(key.WithKeys("N"), key.WithHelp("N", "previous match")),
+		searchFilter: key.NewBinding(
+			key.WithKeys("\\"),
+			key.WithHelp("\\", "toggle showing only matches"),
+		),
+		searchDone:   key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "done")),
+		searchCancel: key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "clear search")),
+		sortColumn:   key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "cycle sort column")),
+		sortDirection: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "toggle sort direction"),
+		),
 		nameScrollLeft: key.NewBinding(
 			key.WithKeys("h", "left"),
 			key.WithHelp("←/h", "scroll name left")),
@@ -136,6 +300,10 @@ func newDatatableKeymap() datatableKeymap {
 			key.WithHelp("→/l", "scroll name right")),
 		selectMode: key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "toggle select mode")),
 		copyURL:    key.NewBinding(key.WithKeys("y"), key.WithHelp("y", "copy URL to clipboard")),
+		copyTimestampedURL: key.NewBinding(
+			key.WithKeys("Y"),
+			key.WithHelp("Y", "copy URL at playback position"),
+		),
 		pasteURL: key.NewBinding(
 			key.WithKeys("p"),
 			key.WithHelp("p", "paste URL from clipboard"),
@@ -74,14 +74,20 @@ func runApp() int {
 	slog.SetDefault(slog.New(handler))
 
 	d := newDownloader(cfg)
-	player := newPlayer()
+	player := newPlayer(cfg)
 	p := tea.NewProgram(
-		newModel(d, player, reader, ctx, cancel, queries, cfg),
+		newModel(d, player, reader, ctx, cancel, db, queries, cfg),
 		tea.WithAltScreen(),
 	)
 	d.setProgram(p)
 	player.setProgram(p)
 
+	if mpris, err := newMPRIS(ctx, player, db, queries, p); err != nil {
+		slog.Warn("mpris interface disabled", slog.String("error", err.Error()))
+	} else {
+		defer mpris.close()
+	}
+
 	go d.start(ctx)
 
 	if _, err := p.Run(); err != nil {
@@ -22,7 +22,17 @@ func openAndMigrateDB() (*sql.DB, error) {
 		return nil, err
 	}
 
-	db, err := sql.Open("sqlite", fmt.Sprintf("file://%s?_pragma=foreign_keys(1)", dbFile))
+	return openDB(dbFile)
+}
+
+// openDB opens the database at dbFile and migrates it to the latest version.
+func openDB(dbFile string) (*sql.DB, error) {
+	// Transactions take the write lock right away and wait for each other, so
+	// two of them reading then writing cannot deadlock.
+	db, err := sql.Open("sqlite", fmt.Sprintf(
+		"file://%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_txlock=immediate",
+		dbFile,
+	))
 	if err != nil {
 		return nil, err
 	}
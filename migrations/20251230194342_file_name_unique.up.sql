@@ -10,8 +10,17 @@ CREATE TABLE videos (
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
-INSERT INTO videos SELECT * FROM old_videos
-ON CONFLICT(name,url) DO UPDATE
-SET name=excluded.name || "-dup" || RANDOM, url=excluded.url || "-dup" || RANDOM;
+INSERT INTO videos
+SELECT
+    id,
+    CASE WHEN EXISTS (SELECT 1 FROM old_videos o WHERE o.name = v.name AND o.id < v.id)
+        THEN name || '-dup' || id ELSE name END,
+    CASE WHEN EXISTS (SELECT 1 FROM old_videos o WHERE o.url = v.url AND o.id < v.id)
+        THEN url || '-dup' || id ELSE url END,
+    location,
+    is_watched,
+    order_index,
+    created_at
+FROM old_videos v;
 
 DROP TABLE old_videos;
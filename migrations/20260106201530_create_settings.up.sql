@@ -0,0 +1,4 @@
+CREATE TABLE settings (
+    key VARCHAR PRIMARY KEY NOT NULL,
+    value VARCHAR NOT NULL
+);
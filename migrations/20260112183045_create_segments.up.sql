@@ -0,0 +1,9 @@
+CREATE TABLE segments (
+    id INTEGER PRIMARY KEY,
+    video_id INTEGER NOT NULL REFERENCES videos(id) ON DELETE CASCADE,
+    category VARCHAR NOT NULL,
+    start_time REAL NOT NULL,
+    end_time REAL NOT NULL
+);
+
+CREATE INDEX segments_video_id ON segments(video_id);
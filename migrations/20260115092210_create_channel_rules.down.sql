@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS channel_rules;
+
+ALTER TABLE videos DROP COLUMN channel;
@@ -0,0 +1,8 @@
+ALTER TABLE videos ADD COLUMN channel VARCHAR;
+
+CREATE TABLE channel_rules (
+    channel VARCHAR PRIMARY KEY NOT NULL,
+    start_offset REAL NOT NULL DEFAULT 0,
+    end_offset REAL NOT NULL DEFAULT 0,
+    speed REAL NOT NULL DEFAULT 1
+);
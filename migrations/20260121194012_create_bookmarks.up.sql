@@ -0,0 +1,9 @@
+CREATE TABLE bookmarks (
+    id INTEGER PRIMARY KEY,
+    video_id INTEGER NOT NULL REFERENCES videos(id) ON DELETE CASCADE,
+    position REAL NOT NULL,
+    note VARCHAR NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX bookmarks_video_id ON bookmarks(video_id);
@@ -0,0 +1,8 @@
+CREATE TABLE subtitles (
+    id INTEGER PRIMARY KEY,
+    video_id INTEGER NOT NULL REFERENCES videos(id) ON DELETE CASCADE,
+    language VARCHAR NOT NULL,
+    path VARCHAR NOT NULL DEFAULT ''
+);
+
+CREATE INDEX subtitles_video_id ON subtitles(video_id);
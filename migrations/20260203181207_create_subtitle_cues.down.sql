@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS videos_delete_subtitle_cues;
+
+DROP TABLE IF EXISTS subtitle_cues;
@@ -0,0 +1,10 @@
+CREATE VIRTUAL TABLE subtitle_cues USING fts5(
+    text,
+    video_id UNINDEXED,
+    start_time UNINDEXED
+);
+
+CREATE TRIGGER videos_delete_subtitle_cues AFTER DELETE ON videos
+BEGIN
+    DELETE FROM subtitle_cues WHERE video_id = old.id;
+END;
@@ -0,0 +1 @@
+ALTER TABLE videos ADD COLUMN source_id INTEGER REFERENCES videos(id) ON DELETE SET NULL;
@@ -0,0 +1,50 @@
+-- Ranks become one second apart timestamps in the same order.
+CREATE TABLE videos_old (
+    id INTEGER PRIMARY KEY,
+    name VARCHAR(255) UNIQUE NOT NULL,
+    url VARCHAR UNIQUE NOT NULL,
+    location VARCHAR NOT NULL,
+    is_watched BOOLEAN DEFAULT FALSE,
+    order_index TIMESTAMP UNIQUE DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    channel VARCHAR,
+    resume_position REAL,
+    source_id INTEGER REFERENCES videos_old(id) ON DELETE SET NULL
+);
+
+INSERT INTO videos_old
+SELECT
+    id,
+    name,
+    url,
+    location,
+    is_watched,
+    datetime('now', printf('-%d seconds', ROW_NUMBER() OVER (ORDER BY rank DESC))),
+    created_at,
+    channel,
+    resume_position,
+    source_id
+FROM videos;
+
+CREATE TEMP TABLE segments_backup AS SELECT * FROM segments;
+CREATE TEMP TABLE bookmarks_backup AS SELECT * FROM bookmarks;
+CREATE TEMP TABLE subtitles_backup AS SELECT * FROM subtitles;
+
+DROP TABLE videos;
+
+ALTER TABLE videos_old RENAME TO videos;
+
+INSERT INTO segments SELECT * FROM segments_backup;
+INSERT INTO bookmarks SELECT * FROM bookmarks_backup;
+INSERT INTO subtitles SELECT * FROM subtitles_backup;
+
+DROP TABLE segments_backup;
+DROP TABLE bookmarks_backup;
+DROP TABLE subtitles_backup;
+
+-- Dropping videos dropped its trigger before deleting the rows so the cues
+-- were left alone.
+CREATE TRIGGER videos_delete_subtitle_cues AFTER DELETE ON videos
+BEGIN
+    DELETE FROM subtitle_cues WHERE video_id = old.id;
+END;
@@ -0,0 +1,52 @@
+-- order_index is replaced by rank, a lexicographic key ordered descending.
+-- Existing orders are kept by numbering the videos in their current order.
+CREATE TABLE videos_new (
+    id INTEGER PRIMARY KEY,
+    name VARCHAR(255) UNIQUE NOT NULL,
+    url VARCHAR UNIQUE NOT NULL,
+    location VARCHAR NOT NULL,
+    is_watched BOOLEAN DEFAULT FALSE,
+    rank VARCHAR UNIQUE NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    channel VARCHAR,
+    resume_position REAL,
+    source_id INTEGER REFERENCES videos_new(id) ON DELETE SET NULL
+);
+
+INSERT INTO videos_new
+SELECT
+    id,
+    name,
+    url,
+    location,
+    is_watched,
+    printf('%08dV', ROW_NUMBER() OVER (ORDER BY order_index, id)),
+    created_at,
+    channel,
+    resume_position,
+    source_id
+FROM videos;
+
+-- Dropping videos deletes the rows referencing it, keep them aside.
+CREATE TEMP TABLE segments_backup AS SELECT * FROM segments;
+CREATE TEMP TABLE bookmarks_backup AS SELECT * FROM bookmarks;
+CREATE TEMP TABLE subtitles_backup AS SELECT * FROM subtitles;
+
+DROP TABLE videos;
+
+ALTER TABLE videos_new RENAME TO videos;
+
+INSERT INTO segments SELECT * FROM segments_backup;
+INSERT INTO bookmarks SELECT * FROM bookmarks_backup;
+INSERT INTO subtitles SELECT * FROM subtitles_backup;
+
+DROP TABLE segments_backup;
+DROP TABLE bookmarks_backup;
+DROP TABLE subtitles_backup;
+
+-- Dropping videos dropped its trigger before deleting the rows so the cues
+-- were left alone.
+CREATE TRIGGER videos_delete_subtitle_cues AFTER DELETE ON videos
+BEGIN
+    DELETE FROM subtitle_cues WHERE video_id = old.id;
+END;
@@ -0,0 +1,15 @@
+CREATE TABLE playlists (
+    id INTEGER PRIMARY KEY,
+    name VARCHAR UNIQUE NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE playlist_videos (
+    playlist_id INTEGER NOT NULL REFERENCES playlists(id) ON DELETE CASCADE,
+    video_id INTEGER NOT NULL REFERENCES videos(id) ON DELETE CASCADE,
+    rank VARCHAR NOT NULL,
+    PRIMARY KEY (playlist_id, video_id),
+    UNIQUE (playlist_id, rank)
+);
+
+CREATE INDEX playlist_videos_video_id ON playlist_videos(video_id);
@@ -0,0 +1,2 @@
+ALTER TABLE videos ADD COLUMN upload_date VARCHAR;
+ALTER TABLE videos ADD COLUMN duration REAL;
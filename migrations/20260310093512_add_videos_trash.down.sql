@@ -0,0 +1,2 @@
+ALTER TABLE videos DROP COLUMN trash_path;
+ALTER TABLE videos DROP COLUMN deleted_at;
@@ -0,0 +1,2 @@
+ALTER TABLE videos ADD COLUMN deleted_at DATETIME;
+ALTER TABLE videos ADD COLUMN trash_path VARCHAR;
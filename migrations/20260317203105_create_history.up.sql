@@ -0,0 +1,7 @@
+CREATE TABLE history (
+    id INTEGER PRIMARY KEY,
+    description VARCHAR NOT NULL,
+    operation TEXT NOT NULL,
+    undone BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,2 @@
+ALTER TABLE videos DROP COLUMN watched_at;
+ALTER TABLE videos DROP COLUMN keep;
@@ -0,0 +1,3 @@
+ALTER TABLE videos ADD COLUMN keep BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE videos ADD COLUMN watched_at DATETIME;
+UPDATE videos SET watched_at = created_at WHERE is_watched;
@@ -0,0 +1 @@
+ALTER TABLE videos ADD COLUMN size INTEGER;
@@ -0,0 +1,373 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/introspect"
+	"github.com/godbus/dbus/v5/prop"
+	"github.com/linnovs/ytqueue/database"
+)
+
+const (
+	mprisPath        dbus.ObjectPath = "/org/mpris/MediaPlayer2"
+	mprisBusName                     = "org.mpris.MediaPlayer2.ytqueue"
+	mprisRootIface                   = "org.mpris.MediaPlayer2"
+	mprisPlayerIface                 = "org.mpris.MediaPlayer2.Player"
+	mprisNoTrack     dbus.ObjectPath = "/org/mpris/MediaPlayer2/TrackList/NoTrack"
+
+	// the range mpv accepts for its speed property
+	mpvMinSpeed = 0.01
+	mpvMaxSpeed = 100.0
+)
+
+// mprisPlayerMethods renames the methods whose D-Bus name clashes with a Go
+// standard method signature.
+var mprisPlayerMethods = map[string]string{"SeekBy": "Seek"} // nolint: gochecknoglobals
+
+type (
+	// skipVideoMsg asks the datatable to play the next or previous video.
+	skipVideoMsg   struct{ previous bool }
+	requestQuitMsg struct{}
+	mprisRoot      struct{ m *mpris }
+	mprisPlayer    struct{ m *mpris }
+)
+
+// mpris exports the player on the session bus so media keys, desktop widgets
+// and playerctl can control it.
+type mpris struct {
+	conn      *dbus.Conn
+	props     *prop.Properties
+	player    *player
+	datastore *datastore
+	program   *tea.Program
+	ctx       context.Context
+	mu        sync.Mutex
+	trackID   string
+	duration  time.Duration
+}
+
+func mprisTrackPath(id string) dbus.ObjectPath {
+	if id == "" {
+		return mprisNoTrack
+	}
+
+	return dbus.ObjectPath("/org/linnovs/ytqueue/video/" + id)
+}
+
+func mprisEmptyMetadata() map[string]dbus.Variant {
+	return map[string]dbus.Variant{"mpris:trackid": dbus.MakeVariant(mprisNoTrack)}
+}
+
+func mprisPlaybackStatus(status playingStatus) string {
+	switch status {
+	case playingStatusPlaying:
+		return "Playing"
+	case playingStatusPaused:
+		return "Paused"
+	case playingStatusStopped:
+	}
+
+	return "Stopped"
+}
+
+func newMPRIS(
+	ctx context.Context,
+	player *player,
+	db *sql.DB,
+	queries *database.Queries,
+	program *tea.Program,
+) (*mpris, error) {
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to session bus: %w", err)
+	}
+
+	m := &mpris{
+		conn:      conn,
+		player:    player,
+		datastore: newDatastore(db, queries),
+		program:   program,
+		ctx:       ctx,
+	}
+
+	if err := m.export(); err != nil {
+		conn.Close() // nolint:errcheck
+		return nil, err
+	}
+
+	player.addObserver(m)
+
+	return m, nil
+}
+
+func (m *mpris) export() error {
+	props, err := prop.Export(m.conn, mprisPath, prop.Map{
+		mprisRootIface: {
+			"CanQuit":             {Value: true, Emit: prop.EmitConst},
+			"CanRaise":            {Value: false, Emit: prop.EmitConst},
+			"HasTrackList":        {Value: false, Emit: prop.EmitConst},
+			"Identity":            {Value: "ytqueue", Emit: prop.EmitConst},
+			"SupportedUriSchemes": {Value: []string{}, Emit: prop.EmitConst},
+			"SupportedMimeTypes":  {Value: []string{}, Emit: prop.EmitConst},
+		},
+		mprisPlayerIface: {
+			"PlaybackStatus": {Value: "Stopped", Emit: prop.EmitTrue},
+			"Metadata":       {Value: mprisEmptyMetadata(), Emit: prop.EmitTrue},
+			"Position":       {Value: int64(0), Emit: prop.EmitFalse},
+			"Volume":         {Value: float64(1), Emit: prop.EmitTrue},
+			"Rate":           {Value: float64(1), Emit: prop.EmitTrue},
+			"MinimumRate":    {Value: mpvMinSpeed, Emit: prop.EmitConst},
+			"MaximumRate":    {Value: mpvMaxSpeed, Emit: prop.EmitConst},
+			"CanGoNext":      {Value: true, Emit: prop.EmitConst},
+			"CanGoPrevious":  {Value: true, Emit: prop.EmitConst},
+			"CanPlay":        {Value: true, Emit: prop.EmitConst},
+			"CanPause":       {Value: true, Emit: prop.EmitConst},
+			"CanSeek":        {Value: true, Emit: prop.EmitConst},
+			"CanControl":     {Value: true, Emit: prop.EmitConst},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export mpris properties: %w", err)
+	}
+
+	m.props = props
+	root, player := mprisRoot{m}, mprisPlayer{m}
+
+	if err := m.conn.Export(root, mprisPath, mprisRootIface); err != nil {
+		return fmt.Errorf("failed to export mpris root object: %w", err)
+	}
+
+	if err := m.conn.ExportWithMap(
+		player,
+		mprisPlayerMethods,
+		mprisPath,
+		mprisPlayerIface,
+	); err != nil {
+		return fmt.Errorf("failed to export mpris player object: %w", err)
+	}
+
+	playerMethods := introspect.Methods(player)
+	for i := range playerMethods {
+		if name, ok := mprisPlayerMethods[playerMethods[i].Name]; ok {
+			playerMethods[i].Name = name
+		}
+	}
+
+	node := &introspect.Node{
+		Name: string(mprisPath),
+		Interfaces: []introspect.Interface{
+			prop.IntrospectData,
+			{
+				Name:       mprisRootIface,
+				Methods:    introspect.Methods(root),
+				Properties: props.Introspection(mprisRootIface),
+			},
+			{
+				Name:       mprisPlayerIface,
+				Methods:    playerMethods,
+				Properties: props.Introspection(mprisPlayerIface),
+				Signals: []introspect.Signal{{
+					Name: "Seeked",
+					Args: []introspect.Arg{{Name: "Position", Type: "x"}},
+				}},
+			},
+		},
+	}
+
+	if err := m.conn.Export(
+		introspect.NewIntrospectable(node),
+		mprisPath,
+		"org.freedesktop.DBus.Introspectable",
+	); err != nil {
+		return fmt.Errorf("failed to export mpris introspection: %w", err)
+	}
+
+	return m.requestName()
+}
+
+// requestName takes the well-known name, another running instance makes us
+// fall back to a per process name as the specification suggests.
+func (m *mpris) requestName() error {
+	names := []string{mprisBusName, fmt.Sprintf("%s.instance%d", mprisBusName, os.Getpid())}
+
+	for _, name := range names {
+		reply, err := m.conn.RequestName(name, dbus.NameFlagDoNotQueue)
+		if err != nil {
+			return fmt.Errorf("failed to request bus name: %w", err)
+		}
+
+		if reply == dbus.RequestNameReplyPrimaryOwner {
+			slog.Debug("mpris bus name acquired", slog.String("name", name))
+			return nil
+		}
+	}
+
+	return fmt.Errorf("bus name %s is already taken", mprisBusName)
+}
+
+func (m *mpris) close() {
+	if err := m.conn.Close(); err != nil {
+		slog.Error("failed to close session bus", slog.String("error", err.Error()))
+	}
+}
+
+func (m *mpris) metadata(id string, duration time.Duration) map[string]dbus.Variant {
+	if id == "" {
+		return mprisEmptyMetadata()
+	}
+
+	metadata := map[string]dbus.Variant{
+		"mpris:trackid": dbus.MakeVariant(mprisTrackPath(id)),
+	}
+
+	if duration > 0 {
+		metadata["mpris:length"] = dbus.MakeVariant(duration.Microseconds())
+	}
+
+	video, err := m.datastore.getVideo(m.ctx, id)
+	if err != nil {
+		slog.Error("failed to load mpris metadata", slog.String("error", err.Error()))
+		return metadata
+	}
+
+	metadata["xesam:title"] = dbus.MakeVariant(
+		strings.TrimSuffix(video.Name, filepath.Ext(video.Name)),
+	)
+	metadata["xesam:url"] = dbus.MakeVariant(video.Url)
+
+	if video.Channel != nil {
+		metadata["xesam:artist"] = dbus.MakeVariant([]string{*video.Channel})
+	}
+
+	return metadata
+}
+
+func (m *mpris) setIfChanged(iface, name string, value any) {
+	if m.props.GetMust(iface, name) == value {
+		return
+	}
+
+	m.props.SetMust(iface, name, value)
+}
+
+// playerChanged mirrors the player state into the properties, a changed
+// value emits PropertiesChanged.
+func (m *mpris) playerChanged() {
+	const maxVolume = 100
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	status := mprisPlaybackStatus(m.player.getPlaying())
+	m.setIfChanged(mprisPlayerIface, "PlaybackStatus", status)
+	m.setIfChanged(mprisPlayerIface, "Volume", m.player.getVolume()/maxVolume)
+	m.setIfChanged(mprisPlayerIface, "Rate", m.player.getSpeed())
+	m.props.SetMust(mprisPlayerIface, "Position", m.player.getPlaytime().Microseconds())
+
+	id, duration := m.player.getCurrentlyPlayingId(), m.player.getDuration()
+	if !m.player.isRunning() {
+		id = ""
+	}
+
+	if id == m.trackID && duration == m.duration {
+		return
+	}
+
+	m.trackID, m.duration = id, duration
+	m.props.SetMust(mprisPlayerIface, "Metadata", m.metadata(id, duration))
+}
+
+func (m *mpris) playerSeeked() {
+	position := m.player.getPlaytime().Microseconds()
+	m.props.SetMust(mprisPlayerIface, "Position", position)
+
+	if err := m.conn.Emit(mprisPath, mprisPlayerIface+".Seeked", position); err != nil {
+		slog.Error("failed to emit mpris seeked signal", slog.String("error", err.Error()))
+	}
+}
+
+func (m *mpris) command(args ...any) *dbus.Error {
+	if !m.player.isRunning() {
+		return nil
+	}
+
+	if err := m.player.sendMPVCommand(args...); err != nil {
+		return dbus.MakeFailedError(err)
+	}
+
+	return nil
+}
+
+func (r mprisRoot) Raise() *dbus.Error {
+	return nil
+}
+
+func (r mprisRoot) Quit() *dbus.Error {
+	r.m.program.Send(requestQuitMsg{})
+	return nil
+}
+
+func (p mprisPlayer) Next() *dbus.Error {
+	p.m.program.Send(skipVideoMsg{})
+	return nil
+}
+
+func (p mprisPlayer) Previous() *dbus.Error {
+	p.m.program.Send(skipVideoMsg{previous: true})
+	return nil
+}
+
+func (p mprisPlayer) Pause() *dbus.Error {
+	return p.m.command("set_property", "pause", true)
+}
+
+func (p mprisPlayer) Play() *dbus.Error {
+	return p.m.command("set_property", "pause", false)
+}
+
+func (p mprisPlayer) PlayPause() *dbus.Error {
+	return p.m.command("cycle", "pause")
+}
+
+// Stop unloads the video, the player stays usable for the next one.
+func (p mprisPlayer) Stop() *dbus.Error {
+	if err := p.m.player.unload(); err != nil {
+		return dbus.MakeFailedError(fmt.Errorf("failed to stop playback: %w", err))
+	}
+
+	p.m.program.Send(updateProgressMsg{0})
+	p.m.program.Send(playbackChangedMsg{})
+
+	return nil
+}
+
+func (p mprisPlayer) SeekBy(offset int64) *dbus.Error {
+	return p.m.command("seek", time.Duration(offset*int64(time.Microsecond)).Seconds(), "relative")
+}
+
+func (p mprisPlayer) SetPosition(trackID dbus.ObjectPath, position int64) *dbus.Error {
+	if trackID != mprisTrackPath(p.m.player.getCurrentlyPlayingId()) || position < 0 {
+		return nil
+	}
+
+	return p.m.command(
+		"seek",
+		time.Duration(position*int64(time.Microsecond)).Seconds(),
+		"absolute",
+	)
+}
+
+func (p mprisPlayer) OpenUri(string) *dbus.Error { // nolint:revive
+	return dbus.MakeFailedError(errors.New("opening URIs is not supported"))
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/linnovs/ytqueue/database"
+)
+
+// The test needs a session bus of its own, run it with
+// dbus-run-session -- go test -run MPRIS .
+func TestMPRIS(t *testing.T) {
+	if os.Getenv("DBUS_SESSION_BUS_ADDRESS") == "" {
+		t.Skip("no session bus, run under dbus-run-session")
+	}
+
+	ctx := context.Background()
+
+	db, err := openDB(filepath.Join(t.TempDir(), "videos.db"))
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close() // nolint:errcheck
+
+	queries, err := database.Prepare(ctx, db)
+	if err != nil {
+		t.Fatalf("failed to prepare queries: %v", err)
+	}
+
+	video, err := newDatastore(db, queries).addVideo(ctx, newVideo{
+		name:     "Some video [dQw4w9WgXcQ].mp4",
+		url:      "https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+		location: t.TempDir(),
+	})
+	if err != nil {
+		t.Fatalf("failed to add video: %v", err)
+	}
+
+	// mpv is not started, this process stands in for it and the commands
+	// sent to it are collected.
+	player := newPlayer(&config{})
+
+	if player.process, err = os.FindProcess(os.Getpid()); err != nil {
+		t.Fatal(err)
+	}
+
+	commands := make(chan []any, 8)
+
+	go func() {
+		for command := range player.commandCh {
+			commands <- command
+		}
+	}()
+
+	m, err := newMPRIS(ctx, player, db, queries, nil)
+	if err != nil {
+		t.Fatalf("failed to register service: %v", err)
+	}
+	defer m.close()
+
+	client, err := dbus.SessionBusPrivate()
+	if err != nil {
+		t.Fatalf("failed to connect client: %v", err)
+	}
+	defer client.Close() // nolint:errcheck
+
+	if err := client.Auth(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.Hello(); err != nil {
+		t.Fatal(err)
+	}
+
+	obj := client.Object(mprisBusName, mprisPath)
+
+	property := func(name string) dbus.Variant {
+		t.Helper()
+
+		value, err := obj.GetProperty(mprisPlayerIface + "." + name)
+		if err != nil {
+			t.Fatalf("failed to get %s: %v", name, err)
+		}
+
+		return value
+	}
+
+	if status := property("PlaybackStatus").Value(); status != "Stopped" {
+		t.Errorf("PlaybackStatus = %v, want Stopped", status)
+	}
+
+	id := strconv.FormatInt(video.ID, 10)
+	player.setPlaying(playingStatusPlaying, id)
+	player.notifyChanged()
+
+	if status := property("PlaybackStatus").Value(); status != "Playing" {
+		t.Errorf("PlaybackStatus = %v, want Playing", status)
+	}
+
+	var metadata map[string]dbus.Variant
+	if err := property("Metadata").Store(&metadata); err != nil {
+		t.Fatal(err)
+	}
+
+	if trackID := metadata["mpris:trackid"].Value(); trackID != mprisTrackPath(id) {
+		t.Errorf("mpris:trackid = %v, want %v", trackID, mprisTrackPath(id))
+	}
+
+	if title := metadata["xesam:title"].Value(); title != "Some video [dQw4w9WgXcQ]" {
+		t.Errorf("xesam:title = %v", title)
+	}
+
+	player.setSpeed(1.5)
+	player.notifyChanged()
+
+	if rate := property("Rate").Value(); rate != 1.5 {
+		t.Errorf("Rate = %v, want 1.5", rate)
+	}
+
+	if call := obj.Call(mprisPlayerIface+".PlayPause", 0); call.Err != nil {
+		t.Fatalf("PlayPause failed: %v", call.Err)
+	}
+
+	select {
+	case command := <-commands:
+		if commandToString(command...) != commandToString("cycle", "pause") {
+			t.Errorf("PlayPause sent %v, want cycle pause", command)
+		}
+	case <-time.After(time.Second):
+		t.Error("PlayPause sent no command")
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"database/sql"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/godbus/dbus/v5"
+	"github.com/linnovs/ytqueue/database"
+)
+
+const (
+	notificationsDest  = "org.freedesktop.Notifications"
+	notificationsPath  = "/org/freedesktop/Notifications"
+	notificationsIface = "org.freedesktop.Notifications"
+)
+
+type (
+	queueDrainedMsg struct{}
+	nowPlayingMsg   struct{ id string }
+)
+
+type notifyEvent int
+
+const (
+	notifyDownloadFinished notifyEvent = iota
+	notifyDownloadFailed
+	notifyQueueDrained
+	notifyNowPlaying
+)
+
+// notifier sends freedesktop notifications for the events enabled in the
+// config, it does nothing when the session bus is unavailable.
+type notifier struct {
+	conn      *dbus.Conn
+	enabled   map[notifyEvent]bool
+	datastore *datastore
+	getCtx    contextFn
+	mu        sync.Mutex
+	replaceID uint32 // id of the last now playing notification
+}
+
+func newNotifier(
+	cfg *config,
+	db *sql.DB,
+	queries *database.Queries,
+	getCtx contextFn,
+) *notifier {
+	n := &notifier{
+		enabled: map[notifyEvent]bool{
+			notifyDownloadFinished: cfg.NotifyDownloadFinished,
+			notifyDownloadFailed:   cfg.NotifyDownloadFailed,
+			notifyQueueDrained:     cfg.NotifyQueueDrained,
+			notifyNowPlaying:       cfg.NotifyNowPlaying,
+		},
+		datastore: newDatastore(db, queries),
+		getCtx:    getCtx,
+	}
+
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		slog.Warn("desktop notifications disabled", slog.String("error", err.Error()))
+		return n
+	}
+
+	n.conn = conn
+
+	return n
+}
+
+func (n *notifier) notifyCmd(event notifyEvent, summary, body string) tea.Cmd {
+	if n.conn == nil || !n.enabled[event] {
+		return nil
+	}
+
+	return func() tea.Msg {
+		const expireTimeout = int32(-1) // let the server decide
+
+		var replaceID uint32
+
+		if event == notifyNowPlaying {
+			n.mu.Lock()
+			replaceID = n.replaceID
+			n.mu.Unlock()
+		}
+
+		var id uint32
+
+		if err := n.conn.Object(notificationsDest, notificationsPath).Call(
+			notificationsIface+".Notify",
+			0,
+			"ytqueue",
+			replaceID,
+			"",
+			summary,
+			body,
+			[]string{},
+			map[string]dbus.Variant{},
+			expireTimeout,
+		).Store(&id); err != nil {
+			slog.Error("failed to send notification", slog.String("error", err.Error()))
+			return nil
+		}
+
+		if event == notifyNowPlaying {
+			n.mu.Lock()
+			n.replaceID = id
+			n.mu.Unlock()
+		}
+
+		return nil
+	}
+}
+
+func (n *notifier) nowPlayingCmd(id string) tea.Cmd {
+	if n.conn == nil || !n.enabled[notifyNowPlaying] {
+		return nil
+	}
+
+	return func() tea.Msg {
+		video, err := n.datastore.getVideo(n.getCtx(), id)
+		if err != nil {
+			slog.Error("failed to load now playing video", slog.String("error", err.Error()))
+			return nil
+		}
+
+		title := strings.TrimSuffix(video.Name, filepath.Ext(video.Name))
+
+		return n.notifyCmd(notifyNowPlaying, "Now playing", title)()
+	}
+}
+
+func (n *notifier) Update(msg tea.Msg) (*notifier, tea.Cmd) {
+	switch msg := msg.(type) {
+	case finishDownloadMsg:
+		title := strings.TrimSuffix(msg.filename, filepath.Ext(msg.filename))
+		return n, n.notifyCmd(notifyDownloadFinished, "Download finished", title)
+	case downloadFailedMsg:
+		return n, n.notifyCmd(notifyDownloadFailed, "Download failed", msg.url)
+	case queueDrainedMsg:
+		return n, n.notifyCmd(notifyQueueDrained, "Queue finished", "No more videos to play")
+	case nowPlayingMsg:
+		return n, n.nowPlayingCmd(msg.id)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"github.com/charmbracelet/lipgloss"
+)
+
+const playModeSettingKey = "play_mode"
+
+type playMode int
+
+const (
+	playModeSequentialUp playMode = iota
+	playModeSequentialDown
+	playModeShuffle
+	playModeRepeatOne
+	playModeRepeatAll
+	playModeStopAfterCurrent
+	playModeCount
+)
+
+func (m playMode) String() string {
+	return [...]string{
+		"OLDEST FIRST",
+		"NEWEST FIRST",
+		"SHUFFLE",
+		"REPEAT ONE",
+		"REPEAT ALL",
+		"STOP AFTER CURRENT",
+	}[m]
+}
+
+// key is the value persisted in the settings table, it must stay stable
+// across releases.
+func (m playMode) key() string {
+	return [...]string{"up", "down", "shuffle", "repeat-one", "repeat-all", "stop"}[m]
+}
+
+func (m playMode) next() playMode {
+	return (m + 1) % playModeCount
+}
+
+func parsePlayMode(key string) playMode {
+	for m := range playModeCount {
+		if m.key() == key {
+			return m
+		}
+	}
+
+	return playModeSequentialUp
+}
+
+func renderPlayMode(mode playMode) string {
+	return lipgloss.NewStyle().
+		Bold(true).
+		Padding(0, 1).
+		Background(lipgloss.Color("63")).
+		Foreground(lipgloss.Color("231")).
+		Render(mode.String())
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -12,12 +13,14 @@ import (
 	"github.com/charmbracelet/bubbles/progress"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/linnovs/ytqueue/database"
 )
 
 type (
 	finishPlayingMsg   struct{}
 	playbackChangedMsg struct{}
 	updateProgressMsg  struct{ percent float64 }
+	playerExitedMsg    struct{ err error }
 )
 
 type playingStatus int
@@ -28,7 +31,10 @@ const (
 	playingStatusPaused
 )
 
-const playingStatusLength = 9
+const (
+	playingStatusLength = 9
+	defaultVolume       = 100
+)
 
 func (s playingStatus) String() string {
 	return [...]string{"STOPPED", "PLAYING", "PAUSED"}[s]
@@ -38,19 +44,61 @@ type player struct {
 	program                  *tea.Program
 	playingMu                *sync.RWMutex
 	playing                  playingStatus
+	mode                     playMode
 	currentlyPlayingId       string
 	currentlyPlayingFilename string
+	currentlyPlayingPath     string
+	playOpts                 playOptions
+	chapters                 []chapter
+	chapter                  int
+	tracks                   []track
+	subVisible               bool
+	segments                 []playSegment
+	lastSkipped              int
+	skipCategories           []string
+	clipMarks                []time.Duration
+	screenshotDir            string
+	lastSession              *playerSession
 	processMu                *sync.RWMutex
 	process                  *os.Process
+	quitting                 bool
 	sockPath                 string
 	commandCh                chan []any
 	playtimeMu               *sync.RWMutex
 	playtime                 time.Duration
 	playtimeRemaining        time.Duration
+	duration                 time.Duration
+	volume                   float64
+	speed                    float64
+	sleep                    *sleepTimer
+	observers                []playerObserver
 	progress                 progress.Model
 }
 
-func newPlayer() *player {
+// playerObserver is notified from the mpv event loop, observers are added
+// before the program starts.
+type playerObserver interface {
+	playerChanged()
+	playerSeeked()
+}
+
+func (p *player) addObserver(o playerObserver) {
+	p.observers = append(p.observers, o)
+}
+
+func (p *player) notifyChanged() {
+	for _, o := range p.observers {
+		o.playerChanged()
+	}
+}
+
+func (p *player) notifySeeked() {
+	for _, o := range p.observers {
+		o.playerSeeked()
+	}
+}
+
+func newPlayer(cfg *config) *player {
 	sockPath, err := xdg.RuntimeFile(fmt.Sprintf("ytqueue/mpv.%d.sock", os.Getpid()))
 	if err != nil {
 		slog.Error("unable to get mpv socket path", slog.String("error", err.Error()))
@@ -58,15 +106,25 @@ func newPlayer() *player {
 	}
 
 	sockPath = filepath.Clean(sockPath)
+	cleanupStaleSockets(filepath.Dir(sockPath))
+
 	commandCh := make(chan []any)
 
 	return &player{
-		playingMu:  new(sync.RWMutex),
-		playtimeMu: new(sync.RWMutex),
-		processMu:  new(sync.RWMutex),
-		sockPath:   sockPath,
-		commandCh:  commandCh,
-		progress:   progress.New(progress.WithDefaultGradient(), progress.WithoutPercentage()),
+		playingMu:      new(sync.RWMutex),
+		playtimeMu:     new(sync.RWMutex),
+		processMu:      new(sync.RWMutex),
+		sockPath:       sockPath,
+		commandCh:      commandCh,
+		chapter:        -1,
+		subVisible:     true,
+		lastSkipped:    -1,
+		skipCategories: cfg.SkipCategories,
+		screenshotDir:  cfg.ScreenshotPath,
+		volume:         defaultVolume,
+		speed:          1,
+		sleep:          new(sleepTimer),
+		progress:       progress.New(progress.WithDefaultGradient(), progress.WithoutPercentage()),
 	}
 }
 
@@ -80,7 +138,9 @@ func (p *player) renderPlayProgress(width int) string {
 	playStatus := renderPlayingStatus(p.getPlaying())
 	remaining := renderPlaytimeRemaining(p.getRemainingTime())
 	p.progress.Width = width - w(playStatus) - w(playtime) - w(remaining)
-	playProgress := p.progress.View()
+	marks := append(p.segmentMarks(), p.chapterMarks()...)
+	marks = append(marks, p.clipProgressMarks()...)
+	playProgress := overlayProgressMarks(p.progress.View(), p.progress.Width, marks)
 
 	return lipgloss.JoinHorizontal(
 		lipgloss.Left,
@@ -91,7 +151,61 @@ func (p *player) renderPlayProgress(width int) string {
 	)
 }
 
-func (p *player) play(filePath, id string) error {
+// playerSession is what is needed to resume playback after mpv went away.
+type playerSession struct {
+	id        string
+	filePath  string
+	position  time.Duration
+	opts      playOptions
+	clipMarks []time.Duration
+}
+
+type playOptions struct {
+	start    time.Duration
+	end      time.Duration // offset from the end of the file
+	speed    float64
+	segments []database.Segment
+}
+
+func newPlayOptions(segments []database.Segment, rule *database.ChannelRule) playOptions {
+	opts := playOptions{segments: segments}
+
+	if rule != nil {
+		opts.start = time.Duration(rule.StartOffset * float64(time.Second))
+		opts.end = time.Duration(rule.EndOffset * float64(time.Second))
+		opts.speed = rule.Speed
+	}
+
+	return opts
+}
+
+func (o playOptions) startProperty() string {
+	if o.start <= 0 {
+		return "none"
+	}
+
+	return fmt.Sprintf("%.3f", o.start.Seconds())
+}
+
+// endProperty stops playback end seconds before the end of the file, mpv then
+// reports eof-reached as if the file really ended.
+func (o playOptions) endProperty() string {
+	if o.end <= 0 {
+		return "none"
+	}
+
+	return fmt.Sprintf("-%.3f", o.end.Seconds())
+}
+
+func (o playOptions) speedProperty() float64 {
+	if o.speed <= 0 {
+		return 1
+	}
+
+	return o.speed
+}
+
+func (p *player) play(filePath, id string, opts playOptions) error {
 	if !p.isRunning() {
 		if err := p.startPlayer(); err != nil {
 			return err
@@ -123,17 +237,64 @@ func (p *player) play(filePath, id string) error {
 		slog.String("nextStatus", status.String()),
 	)
 
+	if err := p.sendMPVCommand("set_property", "start", opts.startProperty()); err != nil {
+		return err
+	}
+
+	if err := p.sendMPVCommand("set_property", "end", opts.endProperty()); err != nil {
+		return err
+	}
+
+	if err := p.sendMPVCommand("set_property", "speed", opts.speedProperty()); err != nil {
+		return err
+	}
+
+	// time-pos of the new file must not be checked against the segments of
+	// the previous one, and file-loaded reports the id of the new file
+	p.setPlayingPath(filePath, id, opts)
+	p.setSegments(newPlaySegments(opts.segments, p.skipCategories))
+	p.setClipMarks(nil)
+
 	if err := p.sendMPVCommand("loadfile", filePath, "replace"); err != nil {
 		slog.Error("failed to send loadfile command to mpv", slog.String("error", err.Error()))
 
 		return err
 	}
 
-	defer p.setPlaying(status, id)
+	defer p.setPlaying(status)
 
 	return p.sendMPVCommand(cmds...)
 }
 
+// restart starts mpv again after it exited unexpectedly and resumes the last
+// played file at the position it was at.
+func (p *player) restart() error {
+	if p.isRunning() {
+		return errors.New("player is already running")
+	}
+
+	session := p.getLastSession()
+	if session == nil {
+		return p.startPlayer()
+	}
+
+	p.setPlaying(playingStatusStopped)
+
+	// segments, offsets and speed of the video apply again, only the start
+	// moves to where playback was
+	opts := session.opts
+	opts.start = session.position
+
+	if err := p.play(session.filePath, session.id, opts); err != nil {
+		return err
+	}
+
+	p.setClipMarks(session.clipMarks)
+	p.setLastSession(nil)
+
+	return nil
+}
+
 func (p *player) stop() error {
 	if !p.isRunning() {
 		return nil
@@ -144,18 +305,38 @@ func (p *player) stop() error {
 		return p.sendMPVCommand("set_property", "pause", true)
 	case playingStatusPaused:
 		defer p.setPlaying(playingStatusStopped)
+		p.setQuitting()
+
 		return p.sendMPVCommand("quit")
 	}
 
 	return nil
 }
 
+// unload stops playback and unloads the file, mpv keeps running idle so the
+// next video plays right away. The position is saved to resume from.
+func (p *player) unload() error {
+	if !p.isRunning() || p.getPlaying() == playingStatusStopped {
+		return nil
+	}
+
+	if err := p.sendMPVCommand("write-watch-later-config"); err != nil {
+		return err
+	}
+
+	p.resetPlayback()
+
+	return p.sendMPVCommand("stop")
+}
+
 func (p *player) quit() tea.Cmd {
 	return func() tea.Msg {
 		if !p.isRunning() {
 			return nil
 		}
 
+		p.setQuitting()
+
 		if err := p.sendMPVCommand("quit"); err != nil {
 			return errorMsg{err}
 		}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sponsorBlockChapterPrefix starts the title of the chapters yt-dlp writes for
+// the SponsorBlock segments, those are already shown as segments.
+const sponsorBlockChapterPrefix = "[SponsorBlock]"
+
+// chapterSeekThreshold matches mpv's chapter-seek-threshold default.
+const chapterSeekThreshold = 5 * time.Second
+
+type chapter struct {
+	index int // index in mpv's chapter-list
+	title string
+	start time.Duration
+}
+
+// parseChapters converts mpv's chapter-list property into chapters. The
+// SponsorBlock chapters are left out along with the part of a chapter that
+// follows one, which yt-dlp gives the title of the chapter it split.
+func parseChapters(data any) []chapter {
+	list, ok := data.([]any)
+	if !ok {
+		return nil
+	}
+
+	chapters := make([]chapter, 0, len(list))
+
+	for i, item := range list {
+		fields, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		c := chapter{index: i, title: fmt.Sprintf("Chapter %d", len(chapters)+1)}
+
+		if title, ok := fields["title"].(string); ok && title != "" {
+			c.title = title
+		}
+
+		if strings.HasPrefix(c.title, sponsorBlockChapterPrefix) {
+			continue
+		}
+
+		if n := len(chapters); n > 0 && chapters[n-1].title == c.title {
+			continue
+		}
+
+		if start, ok := fields["time"].(float64); ok {
+			c.start = time.Duration(start * float64(time.Second))
+		}
+
+		chapters = append(chapters, c)
+	}
+
+	return chapters
+}
+
+func (p *player) setChapters(chapters []chapter) {
+	p.playingMu.Lock()
+	defer p.playingMu.Unlock()
+
+	p.chapters = chapters
+}
+
+func (p *player) getChapters() []chapter {
+	p.playingMu.RLock()
+	defer p.playingMu.RUnlock()
+
+	return slices.Clone(p.chapters)
+}
+
+func (p *player) setChapter(idx int) {
+	p.playingMu.Lock()
+	defer p.playingMu.Unlock()
+
+	p.chapter = idx
+}
+
+func (p *player) getChapter() int {
+	p.playingMu.RLock()
+	defer p.playingMu.RUnlock()
+
+	return p.chapter
+}
+
+// currentChapter returns the position in chapters of the one mpv's chapter idx
+// belongs to, or -1 before the first.
+func currentChapter(chapters []chapter, idx int) int {
+	current := -1
+
+	for i, c := range chapters {
+		if c.index > idx {
+			break
+		}
+
+		current = i
+	}
+
+	return current
+}
+
+func (p *player) getChapterTitle() string {
+	p.playingMu.RLock()
+	defer p.playingMu.RUnlock()
+
+	current := currentChapter(p.chapters, p.chapter)
+	if current < 0 {
+		return ""
+	}
+
+	return p.chapters[current].title
+}
+
+// chapterMarks returns a tick at each chapter start, the first chapter is
+// skipped as it usually starts at 0.
+func (p *player) chapterMarks() []progressMark {
+	duration := p.getDuration()
+	if duration <= 0 {
+		return nil
+	}
+
+	chapters := p.getChapters()
+	marks := make([]progressMark, 0, len(chapters))
+
+	for _, c := range chapters {
+		if c.start <= 0 {
+			continue
+		}
+
+		marks = append(marks, newTickMark(float64(c.start)/float64(duration)))
+	}
+
+	return marks
+}
+
+func (p *player) seekChapterCmd(n int) tea.Cmd {
+	return func() tea.Msg {
+		if !p.isRunning() {
+			return nil
+		}
+
+		chapters := p.getChapters()
+		if len(chapters) == 0 {
+			return footerMsgCmd("No chapters in this video", 0)()
+		}
+
+		// mpv would stop at the SponsorBlock chapters, so the chapter to go
+		// to is picked here, going back a few seconds into a chapter goes to
+		// its start as mpv does
+		current := currentChapter(chapters, p.getChapter())
+		if n < 0 && current >= 0 && p.getPlaytime()-chapters[current].start > chapterSeekThreshold {
+			n++
+		}
+
+		target := clamp(current+n, 0, len(chapters)-1)
+
+		err := p.sendMPVCommand("set_property", "chapter", chapters[target].index)
+		if err != nil {
+			return errorMsg{fmt.Errorf("failed to change chapter: %w", err)}
+		}
+
+		return nil
+	}
+}
+
+func (p *player) gotoChapterCmd(idx int) tea.Cmd {
+	return func() tea.Msg {
+		if err := p.sendMPVCommand("set_property", "chapter", idx); err != nil {
+			return errorMsg{fmt.Errorf("failed to jump to chapter: %w", err)}
+		}
+
+		return nil
+	}
+}
+
+func (p *player) chapterPopupCmd() tea.Cmd {
+	chapters := p.getChapters()
+	if !p.isRunning() || len(chapters) == 0 {
+		return footerMsgCmd("No chapters in this video", 0)
+	}
+
+	items := make([]popupItem, 0, len(chapters))
+	for _, c := range chapters {
+		items = append(items, popupItem{
+			label: formatPlaytime(c.start) + "  " + c.title,
+			value: c.index,
+		})
+	}
+
+	popup := newPopup("Chapters", items, func(item popupItem) tea.Cmd {
+		idx, _ := item.value.(int)
+
+		return p.gotoChapterCmd(idx)
+	})
+	popup.cursor = clamp(currentChapter(chapters, p.getChapter()), 0, len(items)-1)
+
+	return openPopupCmd(popup)
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// clipPoints is the number of marks of a complete A/B range.
+const clipPoints = 2
+
+// fileTimestamp formats a playback position for use in a file name.
+func fileTimestamp(d time.Duration) string {
+	var start time.Time
+
+	return start.Add(d).Format("15.04.05.000")
+}
+
+func (p *player) setClipMarks(marks []time.Duration) {
+	p.playingMu.Lock()
+	defer p.playingMu.Unlock()
+
+	p.clipMarks = marks
+}
+
+// getClipRange returns the A/B range of the video currently playing, ok is
+// false until both points are marked.
+func (p *player) getClipRange() (from, to time.Duration, ok bool) {
+	p.playingMu.RLock()
+	defer p.playingMu.RUnlock()
+
+	if len(p.clipMarks) != clipPoints {
+		return 0, 0, false
+	}
+
+	return p.clipMarks[0], p.clipMarks[1], true
+}
+
+// markClipCmd marks A, then B, at the playback position, marking again after
+// B starts a new range.
+func (p *player) markClipCmd() tea.Cmd {
+	return func() tea.Msg {
+		if !p.isRunning() || p.getCurrentlyPlayingId() == "" {
+			return footerMsgCmd("Nothing is playing", 0)()
+		}
+
+		position := p.getPlaytime()
+
+		p.playingMu.Lock()
+		defer p.playingMu.Unlock()
+
+		if len(p.clipMarks) != 1 {
+			p.clipMarks = []time.Duration{position}
+
+			return footerMsgCmd("Marked A at "+formatPlaytime(position)+", press A to mark B", 0)()
+		}
+
+		if position == p.clipMarks[0] {
+			return footerMsgCmd("B must differ from A", 0)()
+		}
+
+		p.clipMarks = append(p.clipMarks, position)
+		slices.Sort(p.clipMarks)
+
+		return footerMsgCmd(fmt.Sprintf(
+			"Marked %s to %s, press E to export the clip",
+			formatPlaytime(p.clipMarks[0]),
+			formatPlaytime(p.clipMarks[1]),
+		), 0)()
+	}
+}
+
+func (p *player) clipProgressMarks() []progressMark {
+	duration := p.getDuration()
+	if duration <= 0 {
+		return nil
+	}
+
+	p.playingMu.RLock()
+	defer p.playingMu.RUnlock()
+
+	switch len(p.clipMarks) {
+	case 1:
+		return []progressMark{newTickMark(float64(p.clipMarks[0]) / float64(duration))}
+	case clipPoints:
+		return []progressMark{{
+			from:  float64(p.clipMarks[0]) / float64(duration),
+			to:    float64(p.clipMarks[1]) / float64(duration),
+			style: lipgloss.NewStyle().Foreground(lipgloss.Color("45")),
+		}}
+	}
+
+	return nil
+}
+
+// screenshotCmd saves the current frame, subtitles included, into the
+// screenshot directory.
+func (p *player) screenshotCmd() tea.Cmd {
+	return func() tea.Msg {
+		name := p.getPlayingFilename()
+		if !p.isRunning() || name == "" {
+			return footerMsgCmd("Nothing is playing", 0)()
+		}
+
+		file := fmt.Sprintf(
+			"%s %s.png",
+			strings.TrimSuffix(name, filepath.Ext(name)),
+			fileTimestamp(p.getPlaytime()),
+		)
+		file = filepath.Join(p.screenshotDir, file)
+
+		if err := p.sendMPVCommand("screenshot-to-file", file, "subtitles"); err != nil {
+			return errorMsg{fmt.Errorf("failed to take screenshot: %w", err)}
+		}
+
+		return footerMsgCmd("Saved screenshot to "+shortenPath(file), 0)()
+	}
+}
@@ -11,8 +11,9 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
-	"sync"
+	"syscall"
 	"time"
 
 	"github.com/adrg/xdg"
@@ -47,6 +48,10 @@ func commandToString(command ...any) string {
 }
 
 func (p *player) sendMPVCommand(command ...any) error {
+	if !p.isRunning() {
+		return errPlayerNotRunning
+	}
+
 	p.commandCh <- command
 
 	slog.Debug("mpv command sent", slog.String("command", commandToString(command...)))
@@ -94,12 +99,41 @@ func (p *player) observePropertyChange(msg mpvEvent) {
 		}
 	case "time-pos":
 		if playtime, ok := msg.Data.(float64); ok {
-			p.setPlaytime(time.Duration(playtime) * time.Second)
+			position := time.Duration(playtime * float64(time.Second))
+			p.setPlaytime(position)
+			p.skipSegmentAt(position)
 		}
 	case "time-remaining":
 		if remaining, ok := msg.Data.(float64); ok {
 			p.setRemainingTime(time.Duration(remaining) * time.Second)
 		}
+	case "duration":
+		if duration, ok := msg.Data.(float64); ok {
+			p.setDuration(time.Duration(duration * float64(time.Second)))
+		}
+	case "track-list":
+		p.setTracks(parseTracks(msg.Data))
+	case "sub-visibility":
+		if visible, ok := msg.Data.(bool); ok {
+			p.setSubVisible(visible)
+		}
+	case "chapter-list":
+		p.setChapters(parseChapters(msg.Data))
+	case "chapter":
+		chapter := -1
+		if idx, ok := msg.Data.(float64); ok {
+			chapter = int(idx)
+		}
+
+		p.setChapter(chapter)
+	case "volume":
+		if volume, ok := msg.Data.(float64); ok && !p.isSleepFading() {
+			p.setVolume(volume)
+		}
+	case "speed":
+		if speed, ok := msg.Data.(float64); ok {
+			p.setSpeed(speed)
+		}
 	case "percent-pos":
 		if percent, ok := msg.Data.(float64); ok {
 			const maxPercent = 100
@@ -119,8 +153,13 @@ func (p *player) handleEvent(msg mpvEvent, raw string) {
 	case "file-loaded":
 		slog.Debug("mpv playback started", slog.String("id", p.getCurrentlyPlayingId()))
 		p.program.Send(playbackChangedMsg{})
+		p.program.Send(nowPlayingMsg{p.getCurrentlyPlayingId()})
+		p.notifyChanged()
 	case "property-change":
 		p.observePropertyChange(msg)
+		p.notifyChanged()
+	case "playback-restart":
+		p.notifySeeked()
 	case "end-file":
 		slog.Debug(
 			"mpv playback ended",
@@ -134,6 +173,8 @@ func (p *player) handleEvent(msg mpvEvent, raw string) {
 		default:
 			p.program.Send(playbackChangedMsg{})
 		}
+
+		p.notifyChanged()
 	default:
 		slog.Debug(
 			"mpv unhandled event received",
@@ -236,20 +277,53 @@ func (p *player) nextPropertyID() int {
 	return int(time.Now().UnixNano() % idRange)
 }
 
-func (p *player) createMPVConn(ctx context.Context, wg *sync.WaitGroup) {
-	var conn net.Conn
-	var err error
+const (
+	mpvConnectTimeout  = 5 * time.Second
+	mpvConnectMinDelay = 10 * time.Millisecond
+	mpvConnectMaxDelay = 500 * time.Millisecond
+)
+
+var (
+	errMPVConnectTimeout = errors.New("timed out connecting to mpv socket")
+	errMPVExited         = errors.New("mpv exited before accepting connections")
+	errPlayerNotRunning  = errors.New("player is not running")
+)
+
+// dialMPV keeps dialing the mpv socket with an exponential backoff until it
+// succeeds, mpv exits (ctx is cancelled) or mpvConnectTimeout is reached.
+func (p *player) dialMPV(ctx context.Context) (net.Conn, error) {
+	ctx, cancel := context.WithTimeout(ctx, mpvConnectTimeout)
+	defer cancel()
+
+	var dialer net.Dialer
+
+	delay := mpvConnectMinDelay
 
 	for {
-		conn, err = net.Dial("unix", p.sockPath)
-		if err != nil {
-			continue
+		conn, err := dialer.DialContext(ctx, "unix", p.sockPath)
+		if err == nil {
+			return conn, nil
 		}
 
-		const delay = 100 * time.Millisecond
-		<-time.After(delay)
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, fmt.Errorf("%w: %w", errMPVConnectTimeout, err)
+			}
+
+			return nil, errMPVExited
+		case <-time.After(delay):
+		}
+
+		delay = min(delay*2, mpvConnectMaxDelay)
+	}
+}
 
-		break
+func (p *player) createMPVConn(ctx context.Context, ready chan<- error) {
+	conn, err := p.dialMPV(ctx)
+	if err != nil {
+		ready <- err
+		return
 	}
 
 	defer closeMPVConn(conn)
@@ -260,8 +334,15 @@ func (p *player) createMPVConn(ctx context.Context, wg *sync.WaitGroup) {
 	p.writeMPVCommand(conn, "observe_property", p.nextPropertyID(), "percent-pos")
 	p.writeMPVCommand(conn, "observe_property", p.nextPropertyID(), "pause")
 	p.writeMPVCommand(conn, "observe_property", p.nextPropertyID(), "filename/no-ext")
+	p.writeMPVCommand(conn, "observe_property", p.nextPropertyID(), "volume")
+	p.writeMPVCommand(conn, "observe_property", p.nextPropertyID(), "speed")
+	p.writeMPVCommand(conn, "observe_property", p.nextPropertyID(), "duration")
+	p.writeMPVCommand(conn, "observe_property", p.nextPropertyID(), "chapter-list")
+	p.writeMPVCommand(conn, "observe_property", p.nextPropertyID(), "chapter")
+	p.writeMPVCommand(conn, "observe_property", p.nextPropertyID(), "track-list")
+	p.writeMPVCommand(conn, "observe_property", p.nextPropertyID(), "sub-visibility")
 
-	wg.Done()
+	ready <- nil
 
 	go p.readMPVEvents(conn)
 	go p.writeMPVCommands(ctx, conn)
@@ -269,23 +350,60 @@ func (p *player) createMPVConn(ctx context.Context, wg *sync.WaitGroup) {
 	<-ctx.Done()
 }
 
-func (p *player) monitorProcess(cmd *exec.Cmd, wg *sync.WaitGroup) {
+// handleExit resets the playback state after mpv exited. Exits we did not ask
+// for are reported to the TUI and remembered so the player can be restarted.
+func (p *player) handleExit(err error, expected bool) {
+	defer p.notifyChanged()
+
+	if expected || err == nil {
+		p.setPlaying(playingStatusStopped)
+		p.program.Send(playbackChangedMsg{})
+
+		return
+	}
+
+	slog.Error("mpv player exited unexpectedly", slog.String("error", err.Error()))
+
+	session := p.resetPlayback()
+	p.program.Send(updateProgressMsg{0})
+
+	if session.filePath == "" {
+		p.program.Send(playbackChangedMsg{})
+
+		return
+	}
+
+	p.setLastSession(&session)
+	p.program.Send(playerExitedMsg{err})
+}
+
+func (p *player) monitorProcess(cmd *exec.Cmd, ready chan<- error) {
 	slog.Debug("mpv player started", slog.Int("pid", cmd.Process.Pid))
 
 	p.processMu.Lock()
 	p.process = cmd.Process
+	p.quitting = false
 	p.processMu.Unlock()
 
 	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 
-	go p.createMPVConn(ctx, wg)
+	go p.createMPVConn(ctx, ready)
 
-	if err := cmd.Wait(); err != nil {
-		slog.Error("mpv player exited with error", slog.String("error", err.Error()))
-	}
+	err := cmd.Wait()
+
+	cancel()
+
+	p.processMu.Lock()
+	expected := p.quitting
+	p.process = nil
+	p.quitting = false
+	p.processMu.Unlock()
+
+	removeSocket(p.sockPath)
 
 	slog.Debug("mpv player exited", slog.Int("pid", cmd.Process.Pid))
+
+	p.handleExit(err, expected)
 }
 
 func (p *player) startPlayer() error {
@@ -299,6 +417,8 @@ func (p *player) startPlayer() error {
 		return err
 	}
 
+	removeSocket(p.sockPath)
+
 	cmd := exec.Command(
 		"mpv",
 		"--save-position-on-quit",
@@ -313,11 +433,61 @@ func (p *player) startPlayer() error {
 		return err
 	}
 
-	var wg sync.WaitGroup
-	wg.Add(1)
+	ready := make(chan error, 1)
 
-	go p.monitorProcess(cmd, &wg)
-	wg.Wait()
+	go p.monitorProcess(cmd, ready)
+
+	if err := <-ready; err != nil {
+		p.setQuitting()
+
+		if err := cmd.Process.Kill(); err != nil && !errors.Is(err, os.ErrProcessDone) {
+			slog.Error("failed to kill mpv player", slog.String("error", err.Error()))
+		}
+
+		return fmt.Errorf("unable to connect to mpv: %w", err)
+	}
 
 	return nil
 }
+
+func removeSocket(sockPath string) {
+	if err := os.Remove(sockPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		slog.Error(
+			"failed to remove mpv socket",
+			slog.String("path", sockPath),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+func isProcessAlive(pid int) bool {
+	err := syscall.Kill(pid, syscall.Signal(0))
+
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// cleanupStaleSockets removes mpv sockets left behind by ytqueue sessions that
+// did not exit cleanly.
+func cleanupStaleSockets(dir string) {
+	socks, err := filepath.Glob(filepath.Join(dir, "mpv.*.sock"))
+	if err != nil {
+		slog.Error("failed to list mpv sockets", slog.String("error", err.Error()))
+		return
+	}
+
+	for _, sock := range socks {
+		pidStr := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(sock), "mpv."), ".sock")
+
+		pid, err := strconv.Atoi(pidStr)
+		if err != nil {
+			continue
+		}
+
+		if pid != os.Getpid() && isProcessAlive(pid) {
+			continue
+		}
+
+		slog.Debug("removing stale mpv socket", slog.String("path", sock))
+		removeSocket(sock)
+	}
+}
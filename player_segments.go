@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/linnovs/ytqueue/database"
+)
+
+type segmentSkippedMsg struct {
+	category string
+	duration time.Duration
+}
+
+// playSegment is a SponsorBlock segment of the video currently playing.
+type playSegment struct {
+	category string
+	start    time.Duration
+	end      time.Duration
+	skip     bool
+	skipped  bool
+}
+
+func newPlaySegments(segments []database.Segment, skipCategories []string) []playSegment {
+	playSegments := make([]playSegment, 0, len(segments))
+
+	for _, s := range segments {
+		playSegments = append(playSegments, playSegment{
+			category: s.Category,
+			start:    time.Duration(s.StartTime * float64(time.Second)),
+			end:      time.Duration(s.EndTime * float64(time.Second)),
+			skip:     slices.Contains(skipCategories, s.Category),
+		})
+	}
+
+	return playSegments
+}
+
+func segmentColor(category string) lipgloss.Color {
+	switch category {
+	case "sponsor":
+		return lipgloss.Color("34")
+	case "selfpromo":
+		return lipgloss.Color("220")
+	case "intro":
+		return lipgloss.Color("45")
+	case "outro":
+		return lipgloss.Color("33")
+	case "interaction":
+		return lipgloss.Color("134")
+	default:
+		return lipgloss.Color("244")
+	}
+}
+
+func (p *player) setSegments(segments []playSegment) {
+	p.playingMu.Lock()
+	defer p.playingMu.Unlock()
+
+	p.segments = segments
+	p.lastSkipped = -1
+}
+
+// skipSegmentAt seeks past the segment containing pos if its category should
+// be skipped, each segment is only skipped once per playback.
+func (p *player) skipSegmentAt(pos time.Duration) {
+	const endTolerance = 500 * time.Millisecond
+
+	p.playingMu.Lock()
+
+	idx := slices.IndexFunc(p.segments, func(s playSegment) bool {
+		return s.skip && !s.skipped && pos >= s.start && pos < s.end-endTolerance
+	})
+	if idx < 0 {
+		p.playingMu.Unlock()
+		return
+	}
+
+	p.segments[idx].skipped = true
+	p.lastSkipped = idx
+	segment := p.segments[idx]
+	p.playingMu.Unlock()
+
+	if err := p.sendMPVCommand("seek", segment.end.Seconds(), "absolute"); err != nil {
+		slog.Error("failed to skip segment", slog.String("error", err.Error()))
+		return
+	}
+
+	slog.Debug(
+		"skipped segment",
+		slog.String("category", segment.category),
+		slog.Duration("start", segment.start),
+		slog.Duration("end", segment.end),
+	)
+
+	p.program.Send(segmentSkippedMsg{
+		category: segment.category,
+		duration: (segment.end - segment.start).Round(time.Second),
+	})
+}
+
+func (p *player) undoSkipCmd() tea.Cmd {
+	return func() tea.Msg {
+		p.playingMu.Lock()
+		if p.lastSkipped < 0 || p.lastSkipped >= len(p.segments) {
+			p.playingMu.Unlock()
+
+			return footerMsgCmd("No skipped segment to undo", 0)()
+		}
+
+		segment := p.segments[p.lastSkipped]
+		p.lastSkipped = -1
+		p.playingMu.Unlock()
+
+		if err := p.sendMPVCommand("seek", segment.start.Seconds(), "absolute"); err != nil {
+			return errorMsg{fmt.Errorf("failed to undo skip: %w", err)}
+		}
+
+		return footerMsgCmd("Undid skip of "+segment.category, 0)()
+	}
+}
+
+func (p *player) segmentMarks() []progressMark {
+	duration := p.getDuration()
+	if duration <= 0 {
+		return nil
+	}
+
+	p.playingMu.RLock()
+	defer p.playingMu.RUnlock()
+
+	marks := make([]progressMark, 0, len(p.segments))
+
+	for _, s := range p.segments {
+		marks = append(marks, progressMark{
+			from:  float64(s.start) / float64(duration),
+			to:    float64(s.end) / float64(duration),
+			style: lipgloss.NewStyle().Foreground(segmentColor(s.category)),
+		})
+	}
+
+	return marks
+}
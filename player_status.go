@@ -29,6 +29,20 @@ func (p *player) setPlaying(playing playingStatus, id ...string) {
 	}
 }
 
+func (p *player) getPlayMode() playMode {
+	p.playingMu.RLock()
+	defer p.playingMu.RUnlock()
+
+	return p.mode
+}
+
+func (p *player) setPlayMode(mode playMode) {
+	p.playingMu.Lock()
+	defer p.playingMu.Unlock()
+
+	p.mode = mode
+}
+
 func (p *player) setPlaytime(playtime time.Duration) {
 	p.playtimeMu.Lock()
 	defer p.playtimeMu.Unlock()
@@ -57,6 +71,48 @@ func (p *player) getRemainingTime() time.Duration {
 	return p.playtimeRemaining
 }
 
+func (p *player) setDuration(duration time.Duration) {
+	p.playtimeMu.Lock()
+	defer p.playtimeMu.Unlock()
+
+	p.duration = duration
+}
+
+func (p *player) getDuration() time.Duration {
+	p.playtimeMu.RLock()
+	defer p.playtimeMu.RUnlock()
+
+	return p.duration
+}
+
+func (p *player) setVolume(volume float64) {
+	p.playtimeMu.Lock()
+	defer p.playtimeMu.Unlock()
+
+	p.volume = volume
+}
+
+func (p *player) getVolume() float64 {
+	p.playtimeMu.RLock()
+	defer p.playtimeMu.RUnlock()
+
+	return p.volume
+}
+
+func (p *player) setSpeed(speed float64) {
+	p.playtimeMu.Lock()
+	defer p.playtimeMu.Unlock()
+
+	p.speed = speed
+}
+
+func (p *player) getSpeed() float64 {
+	p.playtimeMu.RLock()
+	defer p.playtimeMu.RUnlock()
+
+	return p.speed
+}
+
 func (p *player) setPlayingFilename(filename string) {
 	p.playingMu.Lock()
 	defer p.playingMu.Unlock()
@@ -96,3 +152,64 @@ func (p *player) isRunning() bool {
 
 	return true
 }
+
+// setPlayingPath keeps the file playing, its id and the options it was played
+// with, to resume it the same way.
+func (p *player) setPlayingPath(filePath, id string, opts playOptions) {
+	p.playingMu.Lock()
+	defer p.playingMu.Unlock()
+
+	p.currentlyPlayingPath = filePath
+	p.currentlyPlayingId = id
+	p.playOpts = opts
+}
+
+func (p *player) getLastSession() *playerSession {
+	p.playingMu.RLock()
+	defer p.playingMu.RUnlock()
+
+	return p.lastSession
+}
+
+func (p *player) setLastSession(session *playerSession) {
+	p.playingMu.Lock()
+	defer p.playingMu.Unlock()
+
+	p.lastSession = session
+}
+
+// resetPlayback clears all playback state and returns what was playing.
+func (p *player) resetPlayback() playerSession {
+	session := playerSession{position: p.getPlaytime()}
+
+	p.playingMu.Lock()
+	session.id = p.currentlyPlayingId
+	session.filePath = p.currentlyPlayingPath
+	session.opts = p.playOpts
+	session.clipMarks = p.clipMarks
+	p.playing = playingStatusStopped
+	p.currentlyPlayingId = ""
+	p.currentlyPlayingFilename = ""
+	p.currentlyPlayingPath = ""
+	p.playOpts = playOptions{}
+	p.chapters = nil
+	p.chapter = -1
+	p.tracks = nil
+	p.segments = nil
+	p.lastSkipped = -1
+	p.clipMarks = nil
+	p.playingMu.Unlock()
+
+	p.setPlaytime(0)
+	p.setRemainingTime(0)
+	p.setDuration(0)
+
+	return session
+}
+
+func (p *player) setQuitting() {
+	p.processMu.Lock()
+	defer p.processMu.Unlock()
+
+	p.quitting = true
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type track struct {
+	id       int
+	kind     string // audio, sub or video
+	lang     string
+	title    string
+	selected bool
+	external bool
+}
+
+// trackOption is the value of a tracks popup item, a zero id disables the
+// track kind.
+type trackOption struct {
+	kind string
+	id   int
+}
+
+// toggleSubtitlesOption is the value of the popup item toggling subtitles.
+type toggleSubtitlesOption struct{}
+
+// parseTracks converts mpv's track-list property into tracks.
+func parseTracks(data any) []track {
+	list, ok := data.([]any)
+	if !ok {
+		return nil
+	}
+
+	tracks := make([]track, 0, len(list))
+
+	for _, item := range list {
+		fields, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		var t track
+
+		if id, ok := fields["id"].(float64); ok {
+			t.id = int(id)
+		}
+
+		t.kind, _ = fields["type"].(string)
+		t.lang, _ = fields["lang"].(string)
+		t.title, _ = fields["title"].(string)
+		t.selected, _ = fields["selected"].(bool)
+		t.external, _ = fields["external"].(bool)
+
+		tracks = append(tracks, t)
+	}
+
+	return tracks
+}
+
+func (t track) label() string {
+	parts := make([]string, 0)
+
+	if t.lang != "" {
+		parts = append(parts, t.lang)
+	}
+
+	if t.title != "" {
+		parts = append(parts, t.title)
+	}
+
+	if len(parts) == 0 {
+		parts = append(parts, fmt.Sprintf("Track %d", t.id))
+	}
+
+	if t.external {
+		parts = append(parts, "(external)")
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func selectedMark(selected bool) string {
+	if selected {
+		return "● "
+	}
+
+	return "  "
+}
+
+func (p *player) setTracks(tracks []track) {
+	p.playingMu.Lock()
+	defer p.playingMu.Unlock()
+
+	p.tracks = tracks
+}
+
+func (p *player) getTracks() []track {
+	p.playingMu.RLock()
+	defer p.playingMu.RUnlock()
+
+	return slices.Clone(p.tracks)
+}
+
+func (p *player) setSubVisible(visible bool) {
+	p.playingMu.Lock()
+	defer p.playingMu.Unlock()
+
+	p.subVisible = visible
+}
+
+func (p *player) isSubVisible() bool {
+	p.playingMu.RLock()
+	defer p.playingMu.RUnlock()
+
+	return p.subVisible
+}
+
+func (p *player) selectTrackCmd(opt trackOption) tea.Cmd {
+	return func() tea.Msg {
+		property := map[string]string{"sub": "sid", "audio": "aid"}[opt.kind]
+
+		var value any = opt.id
+		if opt.id == 0 {
+			value = "no"
+		}
+
+		if err := p.sendMPVCommand("set_property", property, value); err != nil {
+			return errorMsg{fmt.Errorf("failed to select track: %w", err)}
+		}
+
+		return nil
+	}
+}
+
+func (p *player) toggleSubtitlesCmd() tea.Cmd {
+	return func() tea.Msg {
+		if !p.isRunning() {
+			return nil
+		}
+
+		visible := !p.isSubVisible()
+
+		if err := p.sendMPVCommand("set_property", "sub-visibility", visible); err != nil {
+			return errorMsg{fmt.Errorf("failed to toggle subtitles: %w", err)}
+		}
+
+		if visible {
+			return footerMsgCmd("Subtitles shown", 0)()
+		}
+
+		return footerMsgCmd("Subtitles hidden", 0)()
+	}
+}
+
+func (p *player) tracksPopupCmd() tea.Cmd {
+	tracks := p.getTracks()
+	if !p.isRunning() || len(tracks) == 0 {
+		return footerMsgCmd("No tracks to select", 0)
+	}
+
+	items := make([]popupItem, 0, len(tracks)+2)
+	subSelected := false
+
+	for _, kind := range []string{"sub", "audio"} {
+		for _, t := range tracks {
+			if t.kind != kind {
+				continue
+			}
+
+			subSelected = subSelected || (kind == "sub" && t.selected)
+			name := map[string]string{"sub": "Subtitle", "audio": "Audio"}[kind]
+			items = append(items, popupItem{
+				label: selectedMark(t.selected) + name + ": " + t.label(),
+				value: trackOption{kind: kind, id: t.id},
+			})
+		}
+	}
+
+	items = append(items,
+		popupItem{
+			label: selectedMark(!subSelected) + "Subtitle: off",
+			value: trackOption{kind: "sub"},
+		},
+		popupItem{label: "  Toggle subtitle visibility", value: toggleSubtitlesOption{}},
+	)
+
+	return openPopupCmd(newPopup("Tracks", items, func(item popupItem) tea.Cmd {
+		switch v := item.value.(type) {
+		case trackOption:
+			return p.selectTrackCmd(v)
+		case toggleSubtitlesOption:
+			return p.toggleSubtitlesCmd()
+		}
+
+		return nil
+	}))
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/charmbracelet/bubbles/progress"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
 )
 
 type playingNow struct {
@@ -12,6 +13,7 @@ type playingNow struct {
 	style         lipgloss.Style
 	header        string
 	filenameStyle lipgloss.Style
+	chapterStyle  lipgloss.Style
 	progress      progress.Model
 	getCtx        contextFn
 }
@@ -25,6 +27,7 @@ func newPlayingNow(player *player, getCtx contextFn) *playingNow {
 		Background(lipgloss.Color("48")).
 		Foreground(lipgloss.Color("231")).SetString("Playing Now")
 	filenameStyle := headerStyle.UnsetString().Background(lipgloss.Color("99"))
+	chapterStyle := headerStyle.UnsetString().Background(lipgloss.Color("134"))
 	pp := progress.New(progress.WithDefaultGradient(), progress.WithoutPercentage())
 
 	return &playingNow{
@@ -32,6 +35,7 @@ func newPlayingNow(player *player, getCtx contextFn) *playingNow {
 		style:         style,
 		header:        headerStyle.Render(),
 		filenameStyle: filenameStyle,
+		chapterStyle:  chapterStyle,
 		progress:      pp,
 		getCtx:        getCtx,
 	}
@@ -51,18 +55,46 @@ func (p *playingNow) Update(msg tea.Msg) (*playingNow, tea.Cmd) {
 		model, cmd := p.progress.Update(msg)
 		cmds = append(cmds, cmd)
 		p.progress = model.(progress.Model)
+	case sleepTimerTickMsg:
+		if p.player.isSleepTimerTag(msg.tag) {
+			cmds = append(cmds, p.player.sleepTimerTick, sleepTimerTickCmd(msg.tag))
+		}
 	}
 
 	return p, tea.Batch(cmds...)
 }
 
+func (p *playingNow) renderChapter() string {
+	const maxChapterWidth = 40
+
+	title := p.player.getChapterTitle()
+	if title == "" {
+		return ""
+	}
+
+	return p.chapterStyle.Render(runewidth.Truncate(title, maxChapterWidth, "…"))
+}
+
 func (p *playingNow) renderHeader() string {
-	filenameStyle := p.filenameStyle.Width(p.width - lipgloss.Width(p.header))
+	w := lipgloss.Width
+	mode := renderPlayMode(p.player.getPlayMode())
+	sleep := renderSleepTimer(p.player.sleepTimerLabel())
+	chapter := p.renderChapter()
+	filenameWidth := p.width - w(p.header) - w(chapter) - w(sleep) - w(mode)
+	filenameStyle := p.filenameStyle.Width(filenameWidth)
+	filename := runewidth.Truncate(
+		p.player.getPlayingFilename(),
+		filenameWidth-filenameStyle.GetHorizontalPadding(),
+		"…",
+	)
 
 	bar := lipgloss.JoinHorizontal(
 		lipgloss.Top,
 		p.header,
-		filenameStyle.Render(p.player.getPlayingFilename()),
+		filenameStyle.Render(filename),
+		chapter,
+		sleep,
+		mode,
 	)
 
 	return lipgloss.NewStyle().
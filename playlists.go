@@ -0,0 +1,285 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/linnovs/ytqueue/database"
+)
+
+const (
+	activePlaylistSettingKey = "active_playlist"
+	libraryViewName          = "library (all)"
+)
+
+type (
+	newPlaylistItem    struct{}
+	deletePlaylistItem struct{}
+)
+
+// getPlaylist returns the playlist shown in the datatable, the zero value
+// is the library.
+func (d *datatable) getPlaylist() database.Playlist {
+	d.rowMu.RLock()
+	defer d.rowMu.RUnlock()
+
+	return d.playlist
+}
+
+// loadPlaylistCmd shows the playlist that was active when the app was last
+// closed.
+func (d *datatable) loadPlaylistCmd() tea.Cmd {
+	return func() tea.Msg {
+		value, err := d.datastore.getSetting(d.getCtx(), activePlaylistSettingKey)
+		if err != nil {
+			return errorMsg{fmt.Errorf("failed to load active playlist: %w", err)}
+		}
+
+		if id, err := strconv.ParseInt(value, 10, 0); err == nil {
+			playlist, err := d.datastore.getPlaylist(d.getCtx(), id)
+			if err != nil {
+				return errorMsg{fmt.Errorf("failed to load active playlist: %w", err)}
+			}
+
+			if playlist != nil {
+				d.rowMu.Lock()
+				d.playlist = *playlist
+				d.rowMu.Unlock()
+			}
+		}
+
+		return d.refreshRowsCmd()()
+	}
+}
+
+// switchPlaylistCmd shows the playlist, or the library for the zero value,
+// and remembers it for the next start.
+func (d *datatable) switchPlaylistCmd(playlist database.Playlist) tea.Cmd {
+	return func() tea.Msg {
+		d.rowMu.Lock()
+		d.playlist = playlist
+		d.rowMu.Unlock()
+
+		value := ""
+		if playlist.ID != 0 {
+			value = strconv.FormatInt(playlist.ID, 10)
+		}
+
+		if err := d.datastore.setSetting(d.getCtx(), activePlaylistSettingKey, value); err != nil {
+			return errorMsg{fmt.Errorf("failed to save active playlist: %w", err)}
+		}
+
+		d.cursorMu.Lock()
+		d.cursor = 0
+		d.cursorMu.Unlock()
+
+		if err := d.loadRows(); err != nil {
+			return errorMsg{err}
+		}
+
+		return footerMsgCmd("Showing "+playlistName(playlist), 0)()
+	}
+}
+
+func playlistName(playlist database.Playlist) string {
+	if playlist.ID == 0 {
+		return libraryViewName
+	}
+
+	return playlist.Name
+}
+
+// playlistSwitcherCmd lists the library and the playlists to switch to, along
+// with creating a playlist and deleting the active one.
+func (d *datatable) playlistSwitcherCmd() tea.Cmd {
+	return func() tea.Msg {
+		playlists, err := d.datastore.getPlaylists(d.getCtx())
+		if err != nil {
+			return errorMsg{fmt.Errorf("failed to load playlists: %w", err)}
+		}
+
+		active := d.getPlaylist()
+		items := make([]popupItem, 0, len(playlists)+3) // library, new and delete
+
+		for _, playlist := range slices.Insert(playlists, 0, database.Playlist{}) {
+			marker := "  "
+			if playlist.ID == active.ID {
+				marker = "• "
+			}
+
+			items = append(items, popupItem{label: marker + playlistName(playlist), value: playlist})
+		}
+
+		items = append(items, popupItem{label: "+ New playlist", value: newPlaylistItem{}})
+
+		if active.ID != 0 {
+			items = append(items, popupItem{
+				label: fmt.Sprintf("- Delete playlist %q", active.Name),
+				value: deletePlaylistItem{},
+			})
+		}
+
+		return openPopupMsg{newPopup("Playlists", items, func(item popupItem) tea.Cmd {
+			switch value := item.value.(type) {
+			case database.Playlist:
+				return d.switchPlaylistCmd(value)
+			case newPlaylistItem:
+				return d.newPlaylistPopupCmd(nil)
+			case deletePlaylistItem:
+				return d.deletePlaylistPopupCmd(active)
+			}
+
+			return nil
+		})}
+	}
+}
+
+// newPlaylistPopupCmd asks for the name of a new playlist and switches to it,
+// the videos with ids are added to it first.
+func (d *datatable) newPlaylistPopupCmd(ids []string) tea.Cmd {
+	form := newFormPopup("New playlist", []formField{{label: "Name"}}, func(values []string) tea.Cmd {
+		return func() tea.Msg {
+			name := strings.TrimSpace(values[0])
+			if name == "" {
+				return nil
+			}
+
+			playlist, err := d.datastore.addPlaylist(d.getCtx(), name)
+			if err != nil {
+				return errorMsg{fmt.Errorf("failed to create playlist: %w", err)}
+			}
+
+			if err := d.datastore.addToPlaylist(d.getCtx(), playlist.ID, ids); err != nil {
+				return errorMsg{fmt.Errorf("failed to add to playlist: %w", err)}
+			}
+
+			return d.switchPlaylistCmd(*playlist)()
+		}
+	})
+
+	return openFormPopupCmd(form)
+}
+
+func (d *datatable) deletePlaylistPopupCmd(playlist database.Playlist) tea.Cmd {
+	title := fmt.Sprintf("Delete playlist %q? Its videos stay in the library", playlist.Name)
+	items := []popupItem{{label: "Cancel"}, {label: "Delete", value: playlist}}
+
+	return openPopupCmd(newPopup(title, items, func(item popupItem) tea.Cmd {
+		if item.value == nil {
+			return nil
+		}
+
+		return func() tea.Msg {
+			if err := d.datastore.deletePlaylist(d.getCtx(), playlist.ID); err != nil {
+				return errorMsg{fmt.Errorf("failed to delete playlist: %w", err)}
+			}
+
+			return d.switchPlaylistCmd(database.Playlist{})()
+		}
+	}))
+}
+
+func rowIDs(rows []row) []string {
+	ids := make([]string, 0, len(rows))
+	for _, r := range rows {
+		ids = append(ids, r[colID])
+	}
+
+	return ids
+}
+
+// addToPlaylistPopupCmd lets the video at cursor or the selected videos be
+// added to a playlist other than the one shown.
+func (d *datatable) addToPlaylistPopupCmd(cursor int) tea.Cmd {
+	return func() tea.Msg {
+		ids := rowIDs(d.targetRows(cursor))
+		if len(ids) == 0 {
+			return nil
+		}
+
+		playlists, err := d.datastore.getPlaylists(d.getCtx())
+		if err != nil {
+			return errorMsg{fmt.Errorf("failed to load playlists: %w", err)}
+		}
+
+		active := d.getPlaylist()
+		items := make([]popupItem, 0, len(playlists)+1)
+
+		for _, playlist := range playlists {
+			if playlist.ID != active.ID {
+				items = append(items, popupItem{label: playlist.Name, value: playlist})
+			}
+		}
+
+		items = append(items, popupItem{label: "+ New playlist", value: newPlaylistItem{}})
+
+		title := fmt.Sprintf("Add %d videos to playlist", len(ids))
+		if len(ids) == 1 {
+			title = "Add to playlist"
+		}
+
+		return openPopupMsg{newPopup(title, items, func(item popupItem) tea.Cmd {
+			playlist, ok := item.value.(database.Playlist)
+			if !ok {
+				return d.newPlaylistPopupCmd(ids)
+			}
+
+			return func() tea.Msg {
+				if err := d.datastore.addToPlaylist(d.getCtx(), playlist.ID, ids); err != nil {
+					return errorMsg{fmt.Errorf("failed to add to playlist: %w", err)}
+				}
+
+				if len(ids) == 1 {
+					return footerMsgCmd("Added to "+playlist.Name, 0)()
+				}
+
+				return footerMsgCmd(
+					fmt.Sprintf("Added %d videos to %s", len(ids), playlist.Name),
+					0,
+				)()
+			}
+		})}
+	}
+}
+
+// removeFromPlaylistCmd takes the video at cursor or the selected videos out
+// of the playlist shown, the files and the library are left alone.
+func (d *datatable) removeFromPlaylistCmd(cursor int) tea.Cmd {
+	return func() tea.Msg {
+		playlist := d.getPlaylist()
+		if playlist.ID == 0 {
+			return footerMsgCmd("Switch to a playlist with P to remove videos from it", 0)()
+		}
+
+		ids := rowIDs(d.targetRows(cursor))
+		if len(ids) == 0 {
+			return nil
+		}
+
+		if err := d.datastore.removeFromPlaylist(d.getCtx(), playlist.ID, ids); err != nil {
+			return errorMsg{fmt.Errorf("failed to remove from playlist: %w", err)}
+		}
+
+		rows := slices.DeleteFunc(d.getCopyOfRows(), func(r row) bool {
+			return slices.Contains(ids, r[colID])
+		})
+		d.setRows(rows)
+
+		d.selectModeMu.Lock()
+		d.selectMode = false
+		d.selectModeMu.Unlock()
+
+		d.cursorMu.Lock()
+		d.cursor = clamp(d.cursor, 0, max(len(rows)-1, 0))
+		d.cursorMu.Unlock()
+
+		if len(ids) == 1 {
+			return footerMsgCmd("Removed from "+playlist.Name, 0)()
+		}
+
+		return footerMsgCmd(fmt.Sprintf("Removed %d videos from %s", len(ids), playlist.Name), 0)()
+	}
+}
@@ -1,9 +1,11 @@
 package main
 
 import (
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 func formatPlaytime(d time.Duration) string {
@@ -41,3 +43,38 @@ func renderPlaytime(d time.Duration) string {
 func renderPlaytimeRemaining(d time.Duration) string {
 	return lipgloss.NewStyle().Padding(0, 1).SetString("-").Render(formatPlaytime(d))
 }
+
+// progressMark highlights the part of the progress bar between from and to,
+// both fractions of the bar width. A mark with from == to is drawn as a tick.
+type progressMark struct {
+	from, to float64
+	style    lipgloss.Style
+}
+
+func newTickMark(at float64) progressMark {
+	style := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("231"))
+
+	return progressMark{from: at, to: at, style: style}
+}
+
+// overlayProgressMarks draws the marks on top of the rendered progress bar.
+func overlayProgressMarks(bar string, width int, marks []progressMark) string {
+	if width <= 0 {
+		return bar
+	}
+
+	for _, mark := range marks {
+		from := clamp(int(mark.from*float64(width)), 0, width-1)
+		fill := "█"
+
+		to := clamp(int(mark.to*float64(width)), from, width-1)
+		if mark.from == mark.to {
+			fill = "│"
+		}
+
+		cells := mark.style.Render(strings.Repeat(fill, to-from+1))
+		bar = ansi.Truncate(bar, from, "") + cells + ansi.TruncateLeft(bar, to+1, "")
+	}
+
+	return bar
+}
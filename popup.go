@@ -0,0 +1,144 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+type popupItem struct {
+	label string
+	value any
+}
+
+type popupSelectFn func(item popupItem) tea.Cmd
+
+// modal is implemented by the popups, while one is open it receives all key
+// messages and is rendered in place of the datatable.
+type modal interface {
+	Update(msg tea.Msg) (modal, tea.Cmd)
+	View(width, height int) string
+	helpKeymap() help.KeyMap
+}
+
+type (
+	openPopupMsg  struct{ popup modal }
+	closePopupMsg struct{}
+)
+
+func openPopupCmd(p modal) tea.Cmd {
+	return func() tea.Msg {
+		return openPopupMsg{p}
+	}
+}
+
+func closePopupCmd() tea.Msg {
+	return closePopupMsg{}
+}
+
+// popup is a modal list picker.
+type popup struct {
+	title         string
+	items         []popupItem
+	cursor        int
+	offset        int
+	onSelect      popupSelectFn
+	keymap        popupKeymap
+	style         lipgloss.Style
+	titleStyle    lipgloss.Style
+	selectedStyle lipgloss.Style
+}
+
+func newPopupStyle() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(activeBorderColor).
+		Padding(0, 1)
+}
+
+func newPopupTitleStyle() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Bold(true).
+		Padding(0, 1).
+		Background(activeBorderColor).
+		Foreground(lipgloss.Color("231"))
+}
+
+func newPopup(title string, items []popupItem, onSelect popupSelectFn) *popup {
+	return &popup{
+		title:      title,
+		items:      items,
+		onSelect:   onSelect,
+		keymap:     newPopupKeymap(),
+		style:      newPopupStyle(),
+		titleStyle: newPopupTitleStyle(),
+		selectedStyle: lipgloss.NewStyle().
+			Background(lipgloss.Color("141")).
+			Foreground(lipgloss.Color("229")),
+	}
+}
+
+func (p *popup) helpKeymap() help.KeyMap {
+	return p.keymap
+}
+
+func (p *popup) Update(msg tea.Msg) (modal, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return p, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, p.keymap.close):
+		return p, closePopupCmd
+	case key.Matches(keyMsg, p.keymap.lineUp):
+		p.cursor = clamp(p.cursor-1, 0, len(p.items)-1)
+	case key.Matches(keyMsg, p.keymap.lineDown):
+		p.cursor = clamp(p.cursor+1, 0, len(p.items)-1)
+	case key.Matches(keyMsg, p.keymap.selectItem):
+		if len(p.items) == 0 {
+			return p, closePopupCmd
+		}
+
+		return p, tea.Sequence(closePopupCmd, p.onSelect(p.items[p.cursor]))
+	}
+
+	return p, nil
+}
+
+func (p *popup) View(width, height int) string {
+	const minItems = 1
+
+	innerWidth := width - p.style.GetHorizontalFrameSize()
+	title := p.titleStyle.Render(runewidth.Truncate(p.title, innerWidth, "…"))
+	maxItems := max(height-p.style.GetVerticalFrameSize()-lipgloss.Height(title), minItems)
+
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	} else if p.cursor >= p.offset+maxItems {
+		p.offset = p.cursor - maxItems + 1
+	}
+
+	var s strings.Builder
+
+	for i := p.offset; i < min(len(p.items), p.offset+maxItems); i++ {
+		label := runewidth.Truncate(p.items[i].label, innerWidth, "…")
+		style := lipgloss.NewStyle().Width(innerWidth)
+
+		if i == p.cursor {
+			style = p.selectedStyle.Width(innerWidth)
+		}
+
+		s.WriteString("\n" + style.Render(label))
+	}
+
+	if len(p.items) == 0 {
+		s.WriteString("\n" + lipgloss.NewStyle().Faint(true).Render("Nothing here"))
+	}
+
+	return p.style.Width(innerWidth + p.style.GetHorizontalPadding()).Render(title + s.String())
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type formField struct {
+	label string
+	value string
+}
+
+type formSubmitFn func(values []string) tea.Cmd
+
+// formPopup is a modal with a text input per field, all values are handed to
+// onSubmit when the form is saved.
+type formPopup struct {
+	title      string
+	labels     []string
+	inputs     []textinput.Model
+	focus      int
+	onSubmit   formSubmitFn
+	keymap     formPopupKeymap
+	style      lipgloss.Style
+	titleStyle lipgloss.Style
+	labelStyle lipgloss.Style
+}
+
+func newFormPopup(title string, fields []formField, onSubmit formSubmitFn) *formPopup {
+	labels := make([]string, 0, len(fields))
+	inputs := make([]textinput.Model, 0, len(fields))
+
+	for i, field := range fields {
+		input := textinput.New()
+		input.SetValue(field.value)
+
+		if i == 0 {
+			input.Focus()
+		}
+
+		labels = append(labels, field.label)
+		inputs = append(inputs, input)
+	}
+
+	return &formPopup{
+		title:      title,
+		labels:     labels,
+		inputs:     inputs,
+		onSubmit:   onSubmit,
+		keymap:     newFormPopupKeymap(),
+		style:      newPopupStyle(),
+		titleStyle: newPopupTitleStyle(),
+		labelStyle: lipgloss.NewStyle().Bold(true),
+	}
+}
+
+func openFormPopupCmd(f *formPopup) tea.Cmd {
+	return tea.Batch(openPopupCmd(f), textinput.Blink)
+}
+
+func (f *formPopup) helpKeymap() help.KeyMap {
+	return f.keymap
+}
+
+func (f *formPopup) setFocus(focus int) tea.Cmd {
+	f.inputs[f.focus].Blur()
+	f.focus = (focus + len(f.inputs)) % len(f.inputs)
+
+	return f.inputs[f.focus].Focus()
+}
+
+func (f *formPopup) values() []string {
+	values := make([]string, 0, len(f.inputs))
+
+	for _, input := range f.inputs {
+		values = append(values, strings.TrimSpace(input.Value()))
+	}
+
+	return values
+}
+
+func (f *formPopup) Update(msg tea.Msg) (modal, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(keyMsg, f.keymap.close):
+			return f, closePopupCmd
+		case key.Matches(keyMsg, f.keymap.submit):
+			return f, tea.Sequence(closePopupCmd, f.onSubmit(f.values()))
+		case key.Matches(keyMsg, f.keymap.next):
+			return f, f.setFocus(f.focus + 1)
+		case key.Matches(keyMsg, f.keymap.prev):
+			return f, f.setFocus(f.focus - 1)
+		}
+	}
+
+	var cmd tea.Cmd
+	f.inputs[f.focus], cmd = f.inputs[f.focus].Update(msg)
+
+	return f, cmd
+}
+
+func (f *formPopup) View(width, _ int) string {
+	innerWidth := width - f.style.GetHorizontalFrameSize()
+	rows := make([]string, 0, len(f.inputs)+1)
+	rows = append(rows, f.titleStyle.Render(f.title))
+
+	for i, input := range f.inputs {
+		input.Width = innerWidth - lipgloss.Width(input.Prompt) - 1
+		rows = append(rows, "", f.labelStyle.Render(f.labels[i]), input.View())
+	}
+
+	return f.style.Width(innerWidth + f.style.GetHorizontalPadding()).
+		Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
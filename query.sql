@@ -1,17 +1,231 @@
 -- name: GetVideos :many
-SELECT id, name, url, location, is_watched, order_index, created_at FROM videos ORDER BY order_index DESC;
+SELECT id, name, url, location, is_watched, rank, created_at, channel, resume_position, source_id, upload_date, duration, deleted_at, trash_path, keep, watched_at, size FROM videos WHERE deleted_at IS NULL ORDER BY rank DESC;
+
+-- name: GetVideo :one
+SELECT * FROM videos WHERE id = ?;
 
 -- name: AddVideo :one
-INSERT INTO videos (name, url, location) values (?, ?, ?) RETURNING *;
+INSERT INTO videos (name, url, location, channel, resume_position, source_id, upload_date, duration, rank, size) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING *;
 
 -- name: ToggleWatchedStatus :one
-UPDATE videos SET is_watched = not is_watched WHERE id = ? RETURNING *;
+UPDATE videos SET is_watched = not is_watched, watched_at = CASE WHEN is_watched THEN NULL ELSE CURRENT_TIMESTAMP END WHERE id = ? RETURNING *;
+
+-- name: ToggleVideoKeep :one
+UPDATE videos SET keep = not keep WHERE id = ? RETURNING *;
+
+-- name: SetVideoKeep :exec
+UPDATE videos SET keep = ? WHERE id = ?;
+
+-- name: SetVideoChannel :one
+UPDATE videos SET channel = ? WHERE id = ? RETURNING *;
+
+-- name: GetVideosWithoutSize :many
+SELECT * FROM videos WHERE size IS NULL AND deleted_at IS NULL;
+
+-- name: SetVideoSize :exec
+UPDATE videos SET size = ? WHERE id = ?;
 
 -- name: SetWatchedVideo :one
-UPDATE videos SET is_watched = true WHERE id = ? RETURNING *;
+UPDATE videos SET is_watched = true, watched_at = CURRENT_TIMESTAMP WHERE id = ? RETURNING *;
+
+-- name: UpdateVideoRank :exec
+UPDATE videos SET rank = ? WHERE id = ?;
 
--- name: UpdateVideoOrder :exec
-UPDATE videos SET order_index = ? WHERE id = ?;
+-- name: GetVideoRank :one
+SELECT rank FROM videos WHERE id = ?;
+
+-- name: GetTopVideoID :one
+SELECT id FROM videos ORDER BY rank DESC LIMIT 1;
+
+-- name: GetVideoIDsByRank :many
+SELECT id FROM videos ORDER BY rank;
 
 -- name: DeleteVideo :exec
 DELETE FROM videos WHERE id = ?;
+
+-- name: TrashVideo :exec
+UPDATE videos SET deleted_at = CURRENT_TIMESTAMP, trash_path = ? WHERE id = ?;
+
+-- name: RestoreVideo :exec
+UPDATE videos SET deleted_at = NULL, trash_path = NULL WHERE id = ?;
+
+-- name: RelinkVideo :exec
+UPDATE videos SET name = ?, location = ?, size = ? WHERE id = ?;
+
+-- name: GetTrashedVideos :many
+SELECT id, name, url, location, is_watched, rank, created_at, channel, resume_position, source_id, upload_date, duration, deleted_at, trash_path, keep, watched_at, size FROM videos WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC, id DESC;
+
+-- name: DeleteTrashedVideoByURL :many
+DELETE FROM videos WHERE url = ? AND deleted_at IS NOT NULL RETURNING trash_path;
+
+-- name: GetSetting :one
+SELECT value FROM settings WHERE key = ?;
+
+-- name: SetSetting :exec
+INSERT INTO settings (key, value) VALUES (?, ?)
+ON CONFLICT(key) DO UPDATE SET value = excluded.value;
+
+-- name: AddSegment :exec
+INSERT INTO segments (video_id, category, start_time, end_time) VALUES (?, ?, ?, ?);
+
+-- name: DeleteSegments :exec
+DELETE FROM segments WHERE video_id = ?;
+
+-- name: GetSegments :many
+SELECT * FROM segments WHERE video_id = ? ORDER BY start_time;
+
+-- name: GetChannelRule :one
+SELECT * FROM channel_rules WHERE channel = ?;
+
+-- name: SetChannelRule :exec
+INSERT INTO channel_rules (channel, start_offset, end_offset, speed) VALUES (?, ?, ?, ?)
+ON CONFLICT(channel) DO UPDATE SET
+    start_offset = excluded.start_offset,
+    end_offset = excluded.end_offset,
+    speed = excluded.speed;
+
+-- name: DeleteChannelRule :exec
+DELETE FROM channel_rules WHERE channel = ?;
+
+-- name: AddBookmark :exec
+INSERT INTO bookmarks (video_id, position, note) VALUES (?, ?, ?);
+
+-- name: GetBookmarks :many
+SELECT * FROM bookmarks WHERE video_id = ? ORDER BY position;
+
+-- name: ClearResumePosition :exec
+UPDATE videos SET resume_position = NULL WHERE id = ?;
+
+-- name: AddSubtitle :exec
+INSERT INTO subtitles (video_id, language, path) VALUES (?, ?, ?);
+
+-- name: DeleteSubtitles :exec
+DELETE FROM subtitles WHERE video_id = ?;
+
+-- name: DeleteSubtitleCues :exec
+DELETE FROM subtitle_cues WHERE video_id = ?;
+
+-- name: GetSubtitles :many
+SELECT * FROM subtitles WHERE video_id = ? ORDER BY language;
+
+-- name: AddSubtitleCue :exec
+INSERT INTO subtitle_cues (text, video_id, start_time) VALUES (?, ?, ?);
+
+-- name: SearchSubtitleCues :many
+SELECT
+    subtitle_cues.video_id,
+    subtitle_cues.start_time,
+    CAST(snippet(subtitle_cues, 0, '', '', '…', 12) AS TEXT) AS snippet,
+    videos.name
+FROM subtitle_cues
+JOIN videos ON videos.id = subtitle_cues.video_id
+WHERE subtitle_cues MATCH ? AND videos.deleted_at IS NULL
+ORDER BY subtitle_cues.rank
+LIMIT ?;
+
+-- name: GetUnindexedSubtitles :many
+SELECT subtitles.video_id, subtitles.language, subtitles.path, videos.location, videos.name
+FROM subtitles
+JOIN videos ON videos.id = subtitles.video_id
+WHERE videos.deleted_at IS NULL
+    AND subtitles.video_id NOT IN (SELECT video_id FROM subtitle_cues)
+ORDER BY subtitles.video_id, subtitles.language;
+
+-- name: AddTag :exec
+INSERT INTO tags (name) VALUES (?) ON CONFLICT(name) DO NOTHING;
+
+-- name: TagVideo :exec
+INSERT INTO video_tags (video_id, tag_id)
+SELECT sqlc.arg(video_id), id FROM tags WHERE name = sqlc.arg(name)
+ON CONFLICT DO NOTHING;
+
+-- name: UntagVideo :exec
+DELETE FROM video_tags
+WHERE video_id = sqlc.arg(video_id) AND tag_id IN (SELECT id FROM tags WHERE name = sqlc.arg(name));
+
+-- name: DeleteUnusedTags :exec
+DELETE FROM tags WHERE id NOT IN (SELECT tag_id FROM video_tags);
+
+-- name: GetVideoTags :many
+SELECT video_tags.video_id, tags.name
+FROM video_tags
+JOIN tags ON tags.id = video_tags.tag_id
+ORDER BY tags.name;
+
+-- name: GetPlaylists :many
+SELECT * FROM playlists ORDER BY name;
+
+-- name: GetPlaylist :one
+SELECT * FROM playlists WHERE id = ?;
+
+-- name: AddPlaylist :one
+INSERT INTO playlists (name) VALUES (?) RETURNING *;
+
+-- name: DeletePlaylist :exec
+DELETE FROM playlists WHERE id = ?;
+
+-- name: GetPlaylistVideos :many
+SELECT
+    videos.id,
+    videos.name,
+    videos.url,
+    videos.location,
+    videos.is_watched,
+    videos.rank,
+    videos.created_at,
+    videos.channel,
+    videos.resume_position,
+    videos.source_id,
+    videos.upload_date,
+    videos.duration,
+    videos.deleted_at,
+    videos.trash_path,
+    videos.keep,
+    videos.watched_at,
+    videos.size
+FROM videos
+JOIN playlist_videos ON playlist_videos.video_id = videos.id
+WHERE playlist_videos.playlist_id = ? AND videos.deleted_at IS NULL
+ORDER BY playlist_videos.rank DESC;
+
+-- name: AddPlaylistVideo :exec
+INSERT INTO playlist_videos (playlist_id, video_id, rank) VALUES (?, ?, ?)
+ON CONFLICT(playlist_id, video_id) DO NOTHING;
+
+-- name: RemovePlaylistVideo :exec
+DELETE FROM playlist_videos WHERE playlist_id = ? AND video_id = ?;
+
+-- name: UpdatePlaylistVideoRank :exec
+UPDATE playlist_videos SET rank = ? WHERE playlist_id = ? AND video_id = ?;
+
+-- name: GetPlaylistVideoRank :one
+SELECT rank FROM playlist_videos WHERE playlist_id = ? AND video_id = ?;
+
+-- name: GetTopPlaylistVideoID :one
+SELECT video_id FROM playlist_videos WHERE playlist_id = ? ORDER BY rank DESC LIMIT 1;
+
+-- name: GetPlaylistVideoIDsByRank :many
+SELECT video_id FROM playlist_videos WHERE playlist_id = ? ORDER BY rank;
+
+-- name: AddHistoryEntry :exec
+INSERT INTO history (description, operation) VALUES (?, ?);
+
+-- name: DeleteUndoneHistory :exec
+DELETE FROM history WHERE undone;
+
+-- name: TrimHistory :exec
+DELETE FROM history WHERE id NOT IN (SELECT id FROM history ORDER BY id DESC LIMIT ?);
+
+-- name: GetLastDoneHistoryEntry :one
+SELECT * FROM history WHERE NOT undone ORDER BY id DESC LIMIT 1;
+
+-- name: GetFirstUndoneHistoryEntry :one
+SELECT * FROM history WHERE undone ORDER BY id LIMIT 1;
+
+-- name: SetHistoryEntryUndone :exec
+UPDATE history SET undone = ? WHERE id = ?;
+
+-- name: SetVideoWatched :exec
+UPDATE videos
+SET is_watched = ?1, watched_at = CASE WHEN ?1 THEN COALESCE(?2, CURRENT_TIMESTAMP) END
+WHERE id = ?3;
@@ -0,0 +1,91 @@
+package main
+
+import "strings"
+
+// Videos are ordered by rank, a key compared byte by byte. There is always a
+// key between two others so moving a video only updates that video. Keys grow
+// when videos keep being put between the same neighbours, once a key gets too
+// long all videos are given evenly spread keys again.
+const (
+	rankDigits    = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	maxRankLength = 16
+	// rankHeadroom is how many times more keys than videos are available
+	// after spreading, half of it is left free on either side.
+	rankHeadroom = 4
+)
+
+func rankDigit(key string, i int) int {
+	if i >= len(key) {
+		return 0
+	}
+
+	return strings.IndexByte(rankDigits, key[i])
+}
+
+func rankTail(key string, i int) string {
+	if i >= len(key) {
+		return ""
+	}
+
+	return key[i:]
+}
+
+// rankBetween returns a key sorting after low and before high, an empty low
+// or high leaves that side open. Keys never end with the zero digit so there
+// is always room before a key.
+func rankBetween(low, high string) string {
+	if high != "" {
+		n := 0
+		for n < len(high) && rankDigit(low, n) == rankDigit(high, n) {
+			n++
+		}
+
+		if n > 0 {
+			return high[:n] + rankBetween(rankTail(low, n), high[n:])
+		}
+	}
+
+	lowDigit, highDigit := rankDigit(low, 0), len(rankDigits)
+	if high != "" {
+		highDigit = rankDigit(high, 0)
+	}
+
+	if highDigit-lowDigit > 1 {
+		return string(rankDigits[(lowDigit+highDigit)/2])
+	}
+
+	if len(high) > 1 {
+		return high[:1]
+	}
+
+	return string(rankDigits[lowDigit]) + rankBetween(rankTail(low, 1), "")
+}
+
+// spreadRanks returns n ascending keys evenly spaced in the middle of the
+// shortest key space leaving room for new videos on both ends.
+func spreadRanks(n int) []string {
+	base := len(rankDigits)
+	width, space := 1, base
+
+	for space < rankHeadroom*(n+1) {
+		width++
+		space *= base
+	}
+
+	step := space / 2 / (n + 1)
+	ranks := make([]string, n)
+
+	for i := range ranks {
+		value := space/rankHeadroom + (i+1)*step
+		key := make([]byte, width)
+
+		for j := width - 1; j >= 0; j-- {
+			key[j] = rankDigits[value%base]
+			value /= base
+		}
+
+		ranks[i] = strings.TrimRight(string(key), rankDigits[:1])
+	}
+
+	return ranks
+}
@@ -0,0 +1,429 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/linnovs/ytqueue/database"
+)
+
+// The library is reconciled with the download directory by the [id] yt-dlp
+// puts into file names, it stays when a file is renamed or moved. Files
+// without one are matched by their name, or else by their size and extension
+// when no other new file has them.
+var (
+	fileIDPattern    = regexp.MustCompile(`\[([\w-]+)\]`)
+	youtubeIDPattern = regexp.MustCompile(`^[\w-]{11}$`)
+	mediaExts        = []string{
+		".mp4", ".mkv", ".webm", ".mov", ".avi", ".m4v", ".flv",
+		".m4a", ".mp3", ".opus", ".ogg", ".flac", ".wav",
+	}
+)
+
+type (
+	scanFile struct {
+		path string
+		size int64
+	}
+	movedVideo struct {
+		video database.Video
+		file  scanFile
+	}
+	// scanResult holds the videos whose file is gone, the ones found again
+	// somewhere else and the files no video knows about.
+	scanResult struct {
+		missing []database.Video
+		moved   []movedVideo
+		orphans []scanFile
+	}
+	scanMode      int
+	reconciledMsg struct {
+		description string
+		err         error
+	}
+	reconcileIssue int
+)
+
+const (
+	// scanStartup only tells about the issues found in the footer
+	scanStartup scanMode = iota
+	// scanReview opens the review, or tells that nothing was found
+	scanReview
+	// scanContinue opens the review again while issues are left
+	scanContinue
+)
+
+const (
+	issueMissing reconcileIssue = iota + 1
+	issueMoved
+	issueOrphan
+)
+
+func (r scanResult) empty() bool {
+	return len(r.missing) == 0 && len(r.moved) == 0 && len(r.orphans) == 0
+}
+
+func (r scanResult) String() string {
+	return fmt.Sprintf(
+		"%d missing, %d moved, %d new files",
+		len(r.missing),
+		len(r.moved),
+		len(r.orphans),
+	)
+}
+
+// fileKey returns the name of a file from its last [id] on, so clips of a
+// video have keys of their own, and the id.
+func fileKey(name string) (key, id string) {
+	stem := strings.TrimSuffix(name, filepath.Ext(name))
+
+	matches := fileIDPattern.FindAllStringSubmatchIndex(stem, -1)
+	if len(matches) == 0 {
+		return "", ""
+	}
+
+	last := matches[len(matches)-1]
+
+	return stem[last[0]:], stem[last[2]:last[3]]
+}
+
+func isMediaFile(name string) bool {
+	return slices.Contains(mediaExts, strings.ToLower(filepath.Ext(name)))
+}
+
+// mediaFiles walks dir for the media files in it, hidden directories are
+// skipped.
+func mediaFiles(dir string) ([]scanFile, error) {
+	var files []scanFile
+
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		// directories that cannot be read are left out, not the whole scan
+		if err != nil && path != dir {
+			if entry != nil && entry.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if path != dir && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !entry.Type().IsRegular() || !isMediaFile(entry.Name()) {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		files = append(files, scanFile{path: path, size: info.Size()})
+
+		return nil
+	})
+
+	return files, err
+}
+
+// scanLibrary compares the videos with the media files in dir, a video whose
+// file is gone is relinked to a file nobody knows with the same key, or else
+// the same name, or else the only one without a key of the same size.
+func scanLibrary(dir string, videos []database.Video) (scanResult, error) {
+	var result scanResult
+
+	files, err := mediaFiles(dir)
+	if err != nil {
+		return result, err
+	}
+
+	known := make(map[string]bool, len(videos))
+	for _, video := range videos {
+		known[filepath.Join(video.Location, video.Name)] = true
+	}
+
+	byKey, byName := make(map[string][]int), make(map[string][]int)
+	bySize := make(map[string][]int)
+	claimed := make(map[int]bool)
+
+	for i, file := range files {
+		if known[file.path] {
+			claimed[i] = true
+
+			continue
+		}
+
+		name := filepath.Base(file.path)
+
+		if key, _ := fileKey(name); key != "" {
+			byKey[key] = append(byKey[key], i)
+		} else {
+			key := sizeKey(name, file.size)
+			bySize[key] = append(bySize[key], i)
+		}
+
+		byName[name] = append(byName[name], i)
+	}
+
+	claim := func(candidates []int) (int, bool) {
+		for _, i := range candidates {
+			if !claimed[i] {
+				claimed[i] = true
+
+				return i, true
+			}
+		}
+
+		return 0, false
+	}
+
+	// a size shared by several new files tells nothing about which one it is
+	claimOnly := func(candidates []int) (int, bool) {
+		unclaimed := slices.DeleteFunc(slices.Clone(candidates), func(i int) bool {
+			return claimed[i]
+		})
+
+		if len(unclaimed) != 1 {
+			return 0, false
+		}
+
+		return claim(unclaimed)
+	}
+
+	for _, video := range videos {
+		_, err := os.Stat(filepath.Join(video.Location, video.Name))
+		if !errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+
+		i, found := 0, false
+		if key, _ := fileKey(video.Name); key != "" {
+			i, found = claim(byKey[key])
+		}
+
+		if !found {
+			i, found = claim(byName[video.Name])
+		}
+
+		if !found && video.Size != nil {
+			i, found = claimOnly(bySize[sizeKey(video.Name, *video.Size)])
+		}
+
+		if !found {
+			result.missing = append(result.missing, video)
+
+			continue
+		}
+
+		result.moved = append(result.moved, movedVideo{video: video, file: files[i]})
+	}
+
+	for i, file := range files {
+		if !claimed[i] {
+			result.orphans = append(result.orphans, file)
+		}
+	}
+
+	return result, nil
+}
+
+// sizeKey is what a renamed file without a key is recognized by.
+func sizeKey(name string, size int64) string {
+	return fmt.Sprintf("%d%s", size, strings.ToLower(filepath.Ext(name)))
+}
+
+// importURL gives an imported file the URL of its YouTube video, other files
+// and clips are known by their path.
+func importURL(path string) string {
+	if key, id := fileKey(filepath.Base(path)); key == "["+id+"]" && youtubeIDPattern.MatchString(id) {
+		return "https://www.youtube.com/watch?v=" + id
+	}
+
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
+
+// scanLibraryCmd reconciles the library with the download directory.
+func (d *datatable) scanLibraryCmd(mode scanMode) tea.Cmd {
+	return func() tea.Msg {
+		videos, err := d.datastore.getVideos(d.getCtx(), 0)
+		if err != nil {
+			return errorMsg{fmt.Errorf("failed to load videos: %w", err)}
+		}
+
+		result, err := scanLibrary(d.downloadDir, videos)
+		if err != nil {
+			return errorMsg{fmt.Errorf("failed to scan %s: %w", d.downloadDir, err)}
+		}
+
+		switch {
+		case result.empty() && mode == scanReview:
+			return footerMsgCmd("Library matches the download directory", 0)()
+		case result.empty():
+			return nil
+		case mode == scanStartup:
+			return footerMsgCmd(fmt.Sprintf("Library scan: %s, press L to review", result), 0)()
+		}
+
+		return openPopupMsg{d.reviewPopup(result)}
+	}
+}
+
+func (d *datatable) reviewPopup(result scanResult) *popup {
+	items := make([]popupItem, 0, len(result.moved)+len(result.missing)+len(result.orphans)+3)
+
+	if len(result.moved) > 1 {
+		items = append(items, popupItem{
+			label: fmt.Sprintf("- Relink all %d moved", len(result.moved)),
+			value: issueMoved,
+		})
+	}
+
+	if len(result.orphans) > 1 {
+		items = append(items, popupItem{
+			label: fmt.Sprintf("- Import all %d new files", len(result.orphans)),
+			value: issueOrphan,
+		})
+	}
+
+	if len(result.missing) > 1 {
+		items = append(items, popupItem{
+			label: fmt.Sprintf("- Prune all %d missing", len(result.missing)),
+			value: issueMissing,
+		})
+	}
+
+	for _, moved := range result.moved {
+		items = append(items, popupItem{
+			label: fmt.Sprintf("Moved    %s -> %s", moved.video.Name, d.relPath(moved.file.path)),
+			value: moved,
+		})
+	}
+
+	for _, file := range result.orphans {
+		items = append(items, popupItem{
+			label: fmt.Sprintf("New      %s  %s", d.relPath(file.path), formatBytes(uint64(file.size))),
+			value: file,
+		})
+	}
+
+	for _, video := range result.missing {
+		items = append(items, popupItem{label: "Missing  " + video.Name, value: video})
+	}
+
+	title := "Library scan: " + result.String()
+
+	return newPopup(title, items, func(item popupItem) tea.Cmd {
+		switch value := item.value.(type) {
+		case movedVideo:
+			return d.confirmIssuePopupCmd("Relink", value.video.Name, d.relinkVideosCmd(value))
+		case scanFile:
+			name := filepath.Base(value.path)
+
+			return d.confirmIssuePopupCmd("Import", name, d.importFilesCmd(value))
+		case database.Video:
+			return d.confirmIssuePopupCmd("Prune", value.Name, d.pruneVideosCmd(value))
+		case reconcileIssue:
+			switch value {
+			case issueMoved:
+				return d.relinkVideosCmd(result.moved...)
+			case issueOrphan:
+				return d.importFilesCmd(result.orphans...)
+			case issueMissing:
+				return d.pruneVideosCmd(result.missing...)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (d *datatable) relPath(path string) string {
+	if rel, err := filepath.Rel(d.downloadDir, path); err == nil {
+		return rel
+	}
+
+	return path
+}
+
+func (d *datatable) confirmIssuePopupCmd(action, name string, cmd tea.Cmd) tea.Cmd {
+	items := []popupItem{{label: "Cancel"}, {label: action, value: cmd}}
+
+	return openPopupCmd(newPopup(name, items, func(item popupItem) tea.Cmd {
+		if item.value == nil {
+			return nil
+		}
+
+		return cmd
+	}))
+}
+
+func (d *datatable) relinkVideosCmd(moved ...movedVideo) tea.Cmd {
+	return func() tea.Msg {
+		if err := d.datastore.relinkVideos(d.getCtx(), moved); err != nil {
+			return errorMsg{fmt.Errorf("failed to relink videos: %w", err)}
+		}
+
+		return reconciledMsg{description: fmt.Sprintf("Relinked %d videos", len(moved))}
+	}
+}
+
+// importFilesCmd adds the files to the library, a file that cannot be added,
+// such as one named like a trashed video, is skipped and told about.
+func (d *datatable) importFilesCmd(files ...scanFile) tea.Cmd {
+	return func() tea.Msg {
+		var errs []error
+
+		for _, file := range files {
+			if _, err := d.datastore.addVideo(d.getCtx(), newVideo{
+				name:     filepath.Base(file.path),
+				url:      importURL(file.path),
+				location: filepath.Dir(file.path),
+			}); err != nil {
+				errs = append(errs, fmt.Errorf("failed to import %s: %w", filepath.Base(file.path), err))
+			}
+		}
+
+		return reconciledMsg{
+			description: fmt.Sprintf("Imported %d files", len(files)-len(errs)),
+			err:         errors.Join(errs...),
+		}
+	}
+}
+
+// pruneVideosCmd moves the videos without a file to the trash, so pruning
+// can be undone.
+func (d *datatable) pruneVideosCmd(videos ...database.Video) tea.Cmd {
+	return func() tea.Msg {
+		files := make([]trashedFile, 0, len(videos))
+		for _, video := range videos {
+			files = append(files, trashedFile{
+				videoID: strconv.FormatInt(video.ID, 10),
+				from:    filepath.Join(video.Location, video.Name),
+			})
+		}
+
+		if err := d.trashVideos(files); err != nil {
+			return errorMsg{err}
+		}
+
+		return reconciledMsg{description: fmt.Sprintf("Pruned %d videos (u to undo)", len(videos))}
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var (
+	errRedownloadClip = errors.New("clips are cut from their video, download that again instead")
+	errNoDownloadURL  = errors.New("video has no URL to download it from")
+)
+
+type (
+	redownloadMsg   struct{ req downloadRequest }
+	redownloadedMsg struct{ name string }
+)
+
+// redownloadPopupCmd asks for the format to download the file of the video at
+// cursor in again, the video keeps its place, state and metadata.
+func (d *datatable) redownloadPopupCmd(cursor int) tea.Cmd {
+	return func() tea.Msg {
+		rows := d.getCopyOfRows()
+		if cursor < 0 || cursor >= len(rows) {
+			return nil
+		}
+
+		video, err := d.datastore.getVideo(d.getCtx(), rows[cursor][colID])
+		if err != nil {
+			return errorMsg{fmt.Errorf("failed to load video: %w", err)}
+		}
+
+		if video.SourceID != nil {
+			return errorMsg{errRedownloadClip}
+		}
+
+		if u, err := url.Parse(video.Url); err != nil || !strings.HasPrefix(u.Scheme, "http") {
+			return errorMsg{errNoDownloadURL}
+		}
+
+		fields := []formField{{label: "Format (empty for the default)"}}
+
+		return openFormPopupCmd(newFormPopup(
+			"Download again: "+video.Name,
+			fields,
+			func(values []string) tea.Cmd {
+				return func() tea.Msg {
+					return redownloadMsg{downloadRequest{
+						url:     video.Url,
+						videoID: video.ID,
+						format:  strings.TrimSpace(values[0]),
+					}}
+				}
+			},
+		))()
+	}
+}
+
+// replaceFileCmd points the video to its file downloaded again, the old file
+// and old subtitle files are deleted when the new ones got another name. A
+// video deleted for good meanwhile is added again.
+func (d *datatable) replaceFileCmd(msg finishDownloadMsg) tea.Cmd {
+	return func() tea.Msg {
+		idStr := strconv.FormatInt(msg.videoID, 10)
+
+		old, err := d.datastore.getVideo(d.getCtx(), idStr)
+		if errors.Is(err, sql.ErrNoRows) {
+			msg.videoID = 0
+
+			return d.newVideoCmd(msg)()
+		}
+
+		if err != nil {
+			return errorMsg{fmt.Errorf("failed to load video: %w", err)}
+		}
+
+		oldPath := filepath.Join(old.Location, old.Name)
+		path := filepath.Join(msg.downloadPath, msg.filename)
+
+		video, staleSubtitles, err := d.datastore.replaceVideoFile(
+			d.getCtx(),
+			msg.videoID,
+			path,
+			msg.segments,
+			msg.subtitles,
+		)
+		if err != nil {
+			return errorMsg{fmt.Errorf("failed to update %s: %w", old.Name, err)}
+		}
+
+		rows := d.getCopyOfRows()
+		if idx := slices.IndexFunc(rows, playingIDIndexFunc(idStr)); idx >= 0 {
+			rows[idx] = updatedRow(rows[idx], *video)
+			d.setRows(rows)
+		}
+
+		for _, stale := range staleSubtitles {
+			if err := os.Remove(stale); err != nil && !errors.Is(err, os.ErrNotExist) {
+				slog.Warn(
+					"unable to delete old subtitle",
+					slog.String("path", stale),
+					slog.String("error", err.Error()),
+				)
+			}
+		}
+
+		if oldPath != path {
+			if err := os.Remove(oldPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return errorMsg{fmt.Errorf("failed to delete old file of %s: %w", old.Name, err)}
+			}
+		}
+
+		return redownloadedMsg{video.Name}
+	}
+}
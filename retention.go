@@ -0,0 +1,448 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/linnovs/ytqueue/database"
+)
+
+// Retention rules clean up watched videos in the download directory, at
+// startup and then every interval. Videos marked to keep, unwatched ones and
+// the one playing are never touched.
+const (
+	defaultRetentionInterval = 6 * time.Hour
+	keepMarker               = "📌"
+)
+
+var errRetentionMoveInLibrary = errors.New("retention.move_path must be outside download.path")
+
+type retentionPolicy struct {
+	// watchedAge is how long watched videos are kept, zero keeps them
+	watchedAge time.Duration
+	// maxSize is the size of the library the oldest watched videos are
+	// removed beyond, zero for no limit
+	maxSize uint64
+	// moveDir is where removed videos are moved to, they are deleted when it
+	// is empty
+	moveDir  string
+	interval time.Duration
+}
+
+func newRetentionPolicy(cfg *config) retentionPolicy {
+	return retentionPolicy{
+		watchedAge: time.Duration(cfg.RetentionWatchedDays) * 24 * time.Hour,
+		maxSize:    uint64(cfg.RetentionMaxSizeGiB * gibSize),
+		moveDir:    cfg.RetentionMovePath,
+		interval:   cfg.RetentionInterval,
+	}
+}
+
+func (p retentionPolicy) enabled() bool {
+	return p.watchedAge > 0 || p.maxSize > 0
+}
+
+type (
+	retentionItem struct {
+		video  database.Video
+		size   uint64
+		reason string
+	}
+	// retentionPlan is what the rules remove and how large the library is
+	// before.
+	retentionPlan struct {
+		items       []retentionItem
+		freed       uint64
+		librarySize uint64
+	}
+	retentionAppliedMsg struct {
+		count int
+		freed uint64
+		err   error
+	}
+	retentionTickMsg   struct{}
+	applyRetentionItem struct{}
+)
+
+func isInDir(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// watchedTime is when a video was watched, videos watched before that was
+// kept count from when they were added.
+func watchedTime(v database.Video, now time.Time) time.Time {
+	switch {
+	case v.WatchedAt != nil:
+		return *v.WatchedAt
+	case v.CreatedAt != nil:
+		return *v.CreatedAt
+	}
+
+	return now
+}
+
+// plan picks the watched videos past the age first, then the oldest watched
+// ones until the library fits its size. Only the files in libraryDir count.
+func (p retentionPolicy) plan(
+	videos []database.Video,
+	libraryDir, playingID string,
+	now time.Time,
+) retentionPlan {
+	var plan retentionPlan
+
+	candidates := make([]retentionItem, 0, len(videos))
+
+	for _, video := range videos {
+		path := filepath.Join(video.Location, video.Name)
+		if !isInDir(libraryDir, path) {
+			continue
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+
+		size := uint64(info.Size()) // nolint:gosec
+		plan.librarySize += size
+
+		if video.Keep || !derefOr(video.IsWatched, false) || fmt.Sprint(video.ID) == playingID {
+			continue
+		}
+
+		candidates = append(candidates, retentionItem{video: video, size: size})
+	}
+
+	slices.SortStableFunc(candidates, func(a, b retentionItem) int {
+		return watchedTime(a.video, now).Compare(watchedTime(b.video, now))
+	})
+
+	size := plan.librarySize
+
+	for _, item := range candidates {
+		age := now.Sub(watchedTime(item.video, now))
+
+		switch {
+		case p.watchedAge > 0 && age > p.watchedAge:
+			item.reason = fmt.Sprintf("watched %d days ago", int(age.Hours()/24))
+		case p.maxSize > 0 && size > p.maxSize:
+			item.reason = "over the size limit"
+		default:
+			continue
+		}
+
+		size -= item.size
+		plan.freed += item.size
+		plan.items = append(plan.items, item)
+	}
+
+	return plan
+}
+
+// moveFile moves src to dst, it is copied when dst is on another filesystem.
+// An existing dst is not replaced.
+func moveFile(src, dst string) error {
+	if _, err := os.Lstat(dst); err == nil {
+		return fmt.Errorf("%s already exists", dst)
+	}
+
+	err := os.Rename(src, dst)
+	if !errors.Is(err, syscall.EXDEV) {
+		return err
+	}
+
+	in, err := os.Open(src) // #nosec G304
+	if err != nil {
+		return err
+	}
+	defer in.Close() // nolint:errcheck
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		_ = os.Remove(dst)
+
+		return err
+	}
+
+	if err := out.Close(); err != nil {
+		_ = os.Remove(dst)
+
+		return err
+	}
+
+	return os.Remove(src)
+}
+
+func (d *datatable) planRetention() (retentionPlan, error) {
+	videos, err := d.datastore.getVideos(d.getCtx(), 0)
+	if err != nil {
+		return retentionPlan{}, fmt.Errorf("failed to load videos: %w", err)
+	}
+
+	playingID := d.player.getCurrentlyPlayingId()
+
+	return d.retention.plan(videos, d.downloadDir, playingID, time.Now()), nil
+}
+
+// applyRetention removes the videos of plan and returns how many were removed
+// and the space freed, the ones done before a failure stay removed.
+func (d *datatable) applyRetention(plan retentionPlan) (int, uint64, error) {
+	if d.retention.moveDir != "" {
+		return d.moveRetained(plan)
+	}
+
+	return d.deleteRetained(plan)
+}
+
+// deleteRetained deletes the files of the videos, a trash on the same disk
+// would free nothing. They are moved to the trash first so they can be put
+// back until the videos are hidden, the videos stay in the trash without a
+// file. This cannot be undone.
+func (d *datatable) deleteRetained(plan retentionPlan) (int, uint64, error) {
+	files := make([]trashedFile, 0, len(plan.items))
+	ids := make([]int64, 0, len(plan.items))
+
+	var err error
+
+	for _, item := range plan.items {
+		from := filepath.Join(item.video.Location, item.video.Name)
+
+		var trashPath string
+		if trashPath, err = moveToTrash(from); err != nil {
+			err = fmt.Errorf("%w %s: %w", errTrashFile, item.video.Name, err)
+
+			break
+		}
+
+		files = append(files, trashedFile{
+			videoID: strconv.FormatInt(item.video.ID, 10),
+			from:    from,
+			path:    trashPath,
+		})
+		ids = append(ids, item.video.ID)
+	}
+
+	if len(files) == 0 {
+		return 0, 0, err
+	}
+
+	if trashErr := d.datastore.retireVideos(d.getCtx(), ids); trashErr != nil {
+		for _, file := range files {
+			_ = restoreFromTrash(file.path, file.from)
+		}
+
+		return 0, 0, fmt.Errorf("failed to remove videos: %w", trashErr)
+	}
+
+	var freed uint64
+
+	for i, file := range files {
+		if purgeErr := purgeFromTrash(file.path); purgeErr != nil {
+			name := plan.items[i].video.Name
+			err = errors.Join(err, fmt.Errorf("failed to delete %s from trash: %w", name, purgeErr))
+
+			continue
+		}
+
+		freed += plan.items[i].size
+	}
+
+	return len(files), freed, err
+}
+
+func (d *datatable) moveRetained(plan retentionPlan) (int, uint64, error) {
+	moved := make([]movedVideo, 0, len(plan.items))
+
+	var err error
+
+	for _, item := range plan.items {
+		from := filepath.Join(item.video.Location, item.video.Name)
+		to := filepath.Join(d.retention.moveDir, item.video.Name)
+
+		if err = moveFile(from, to); err != nil {
+			err = fmt.Errorf("failed to move %s: %w", item.video.Name, err)
+
+			break
+		}
+
+		moved = append(moved, movedVideo{video: item.video, file: scanFile{path: to}})
+	}
+
+	if len(moved) == 0 {
+		return 0, 0, err
+	}
+
+	relinkErr := d.datastore.moveVideoFiles(d.getCtx(), moved, d.retention.moveDir)
+	if relinkErr != nil {
+		for _, m := range moved {
+			_ = moveFile(m.file.path, filepath.Join(m.video.Location, m.video.Name))
+		}
+
+		return 0, 0, fmt.Errorf("failed to relink moved videos: %w", relinkErr)
+	}
+
+	var freed uint64
+	for _, item := range plan.items[:len(moved)] {
+		freed += item.size
+	}
+
+	return len(moved), freed, err
+}
+
+func (d *datatable) applyRetentionCmd() tea.Cmd {
+	return func() tea.Msg {
+		plan, err := d.planRetention()
+		if err != nil {
+			return errorMsg{err}
+		}
+
+		if len(plan.items) == 0 {
+			return nil
+		}
+
+		count, freed, err := d.applyRetention(plan)
+
+		return retentionAppliedMsg{count: count, freed: freed, err: err}
+	}
+}
+
+func (d *datatable) scheduleRetentionCmd() tea.Cmd {
+	return tea.Tick(d.retention.interval, func(time.Time) tea.Msg {
+		return retentionTickMsg{}
+	})
+}
+
+func (d *datatable) retentionFooterStr(msg retentionAppliedMsg) string {
+	if d.retention.moveDir != "" {
+		return fmt.Sprintf(
+			"Retention moved %d watched videos (%s) to %s (u to undo)",
+			msg.count,
+			formatBytes(msg.freed),
+			shortenPath(d.retention.moveDir),
+		)
+	}
+
+	return fmt.Sprintf(
+		"Retention deleted %d watched videos (%s), this cannot be undone",
+		msg.count,
+		formatBytes(msg.freed),
+	)
+}
+
+// retentionPreviewCmd lists what the rules would remove now, choosing a
+// video keeps it.
+func (d *datatable) retentionPreviewCmd() tea.Cmd {
+	return func() tea.Msg {
+		if !d.retention.enabled() {
+			return footerMsgCmd("No retention rules in the config", 0)()
+		}
+
+		plan, err := d.planRetention()
+		if err != nil {
+			return errorMsg{err}
+		}
+
+		if len(plan.items) == 0 {
+			footer := fmt.Sprintf(
+				"Retention removes nothing, the library uses %s",
+				formatBytes(plan.librarySize),
+			)
+
+			return footerMsgCmd(footer, 0)()
+		}
+
+		items := make([]popupItem, 0, len(plan.items)+1) // and apply
+		apply := "- Apply now, deleting cannot be undone"
+		if d.retention.moveDir != "" {
+			apply = "- Apply now, moving to " + shortenPath(d.retention.moveDir)
+		}
+
+		items = append(items, popupItem{label: apply, value: applyRetentionItem{}})
+
+		for _, item := range plan.items {
+			items = append(items, popupItem{
+				label: fmt.Sprintf("%s  %s  %s", item.video.Name, formatBytes(item.size), item.reason),
+				value: item.video,
+			})
+		}
+
+		title := fmt.Sprintf(
+			"Retention frees %s of %s (%d videos), choose a video to keep it",
+			formatBytes(plan.freed),
+			formatBytes(plan.librarySize),
+			len(plan.items),
+		)
+
+		return openPopupMsg{newPopup(title, items, func(item popupItem) tea.Cmd {
+			switch value := item.value.(type) {
+			case applyRetentionItem:
+				return d.applyRetentionCmd()
+			case database.Video:
+				return tea.Sequence(d.keepVideoCmd(value.ID), d.retentionPreviewCmd())
+			}
+
+			return nil
+		})}
+	}
+}
+
+// keepVideoCmd marks the video to keep, or not anymore when it was.
+func (d *datatable) keepVideoCmd(id int64) tea.Cmd {
+	return func() tea.Msg {
+		idStr := strconv.FormatInt(id, 10)
+
+		video, err := d.datastore.toggleKeep(d.getCtx(), idStr)
+		if err != nil {
+			return errorMsg{fmt.Errorf("failed to mark video to keep: %w", err)}
+		}
+
+		rows := d.getCopyOfRows()
+		if idx := slices.IndexFunc(rows, playingIDIndexFunc(idStr)); idx >= 0 {
+			rows[idx] = updatedRow(rows[idx], *video)
+			d.setRows(rows)
+		}
+
+		if video.Keep {
+			return footerMsgCmd("Keeping video: "+video.Name, 0)()
+		}
+
+		return footerMsgCmd("No longer keeping video: "+video.Name, 0)()
+	}
+}
+
+func (d *datatable) keepRowCmd(cursor int) tea.Cmd {
+	return func() tea.Msg {
+		rows := d.getCopyOfRows()
+		if cursor < 0 || cursor >= len(rows) {
+			return nil
+		}
+
+		id, err := idStrToInt(rows[cursor][colID])
+		if err != nil {
+			return errorMsg{err}
+		}
+
+		return d.keepVideoCmd(id)()
+	}
+}
@@ -0,0 +1,355 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+	"github.com/sahilm/fuzzy"
+)
+
+// searchColumns are the columns the / search looks in.
+var searchColumns = []column{colName, colURL, colLocation} // nolint: gochecknoglobals
+
+// rowMatch holds the matched byte offsets of each matching column.
+type rowMatch map[column][]int
+
+// tableSearch is the / search of the datatable, rows are highlighted or, in
+// filter mode, hidden when they do not match.
+type tableSearch struct {
+	query      string
+	filterOnly bool
+	filterTag  int
+	matches    map[string]rowMatch // by video id
+}
+
+func (s tableSearch) active() bool {
+	return s.query != ""
+}
+
+type (
+	searchFilterMsg struct{ tag int }
+	rowsReloadedMsg struct{}
+)
+
+func newSearchInput() textinput.Model {
+	input := textinput.New()
+	input.Prompt = "/"
+	input.Placeholder = "search name, URL and location"
+
+	return input
+}
+
+// searchText is the text of col the search looks at, the location is matched
+// the way it is shown.
+func searchText(r row, col column) string {
+	if col == colLocation {
+		return shortenPath(r[col])
+	}
+
+	return r[col]
+}
+
+func matchRow(query string, r row) (rowMatch, bool) {
+	match := make(rowMatch)
+
+	for _, col := range searchColumns {
+		if found := fuzzy.Find(query, []string{searchText(r, col)}); len(found) > 0 {
+			match[col] = found[0].MatchedIndexes
+		}
+	}
+
+	return match, len(match) > 0
+}
+
+func (d *datatable) getSearch() tableSearch {
+	d.searchMu.RLock()
+	defer d.searchMu.RUnlock()
+
+	return d.search
+}
+
+// isSearching reports whether the search input has the keyboard.
+func (d *datatable) isSearching() bool {
+	return d.searchInput.Focused()
+}
+
+// updateSearchMatches finds the rows matching the search.
+func (d *datatable) updateSearchMatches(rows []row) {
+	d.searchMu.Lock()
+	defer d.searchMu.Unlock()
+
+	if !d.search.active() {
+		d.search.matches = nil
+
+		return
+	}
+
+	matches := make(map[string]rowMatch)
+
+	for _, r := range rows {
+		if match, ok := matchRow(d.search.query, r); ok {
+			matches[r[colID]] = match
+		}
+	}
+
+	d.search.matches = matches
+}
+
+// gotoMatch moves the cursor to the first matching row going from row from in
+// direction step, wrapping around the table.
+// this function assumes the caller holds the cursorMu lock.
+func (d *datatable) gotoMatch(from, step int) bool {
+	matches := d.getSearch().matches
+
+	d.rowMu.RLock()
+	defer d.rowMu.RUnlock()
+
+	n := len(d.rows)
+
+	for i := range n {
+		idx := ((from+step*i)%n + n) % n
+		if _, ok := matches[d.rows[idx][colID]]; ok {
+			d.cursor = idx
+			d.nameTruncateLeft = 0
+			d.scrollUp()
+			d.scrollDown()
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// startSearch gives the keyboard to the search input, the search starts at
+// the cursor.
+// this function assumes the caller holds the cursorMu lock.
+func (d *datatable) startSearch() tea.Cmd {
+	d.searchOrigin = d.cursor
+	d.searchInput.SetValue(d.getSearch().query)
+	d.searchInput.CursorEnd()
+
+	return d.searchInput.Focus()
+}
+
+// searchKeyMsgHandler handles the keys typed into the search input, the rows
+// are searched again as the query changes.
+// this function assumes the caller holds the cursorMu lock.
+func (d *datatable) searchKeyMsgHandler(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, d.keymap.searchDone):
+		d.searchInput.Blur()
+
+		if !d.getSearch().active() {
+			return nil
+		}
+
+		return footerMsgCmd("n/N to jump between matches, \\ to show only matches", 0)
+	case key.Matches(msg, d.keymap.searchCancel):
+		d.searchInput.Blur()
+
+		return d.clearSearch()
+	}
+
+	var cmd tea.Cmd
+
+	d.searchInput, cmd = d.searchInput.Update(msg)
+
+	return tea.Batch(cmd, d.setSearchQuery(strings.TrimSpace(d.searchInput.Value())))
+}
+
+// setSearchQuery searches the rows for query, the cursor jumps to the first
+// match from where the search started. In filter mode the rows are loaded
+// again once typing pauses.
+// this function assumes the caller holds the cursorMu lock.
+func (d *datatable) setSearchQuery(query string) tea.Cmd {
+	const searchFilterDebounceDuration = time.Millisecond * 200
+
+	d.searchMu.Lock()
+	changed := d.search.query != query
+	d.search.query = query
+	d.search.filterTag++
+	filterOnly, tag := d.search.filterOnly, d.search.filterTag
+	d.searchMu.Unlock()
+
+	if !changed {
+		return nil
+	}
+
+	if filterOnly {
+		return tea.Tick(searchFilterDebounceDuration, func(_ time.Time) tea.Msg {
+			return searchFilterMsg{tag}
+		})
+	}
+
+	d.updateSearchMatches(d.getCopyOfRows())
+	d.gotoMatch(d.searchOrigin, 1)
+
+	return nil
+}
+
+// clearSearch drops the search, hidden rows are shown again.
+func (d *datatable) clearSearch() tea.Cmd {
+	d.searchMu.Lock()
+	wasFiltered := d.search.filterOnly
+	d.search = tableSearch{filterTag: d.search.filterTag + 1}
+	d.searchMu.Unlock()
+
+	if wasFiltered {
+		return d.reloadRowsCmd()
+	}
+
+	return nil
+}
+
+// toggleSearchFilterCmd switches between highlighting the matching rows and
+// showing only them.
+func (d *datatable) toggleSearchFilterCmd() tea.Cmd {
+	d.searchMu.Lock()
+	defer d.searchMu.Unlock()
+
+	if !d.search.active() {
+		return footerMsgCmd("Search with / first", 0)
+	}
+
+	d.search.filterOnly = !d.search.filterOnly
+
+	return d.reloadRowsCmd()
+}
+
+// nextMatch moves the cursor to the next match, or the previous one with a
+// negative step.
+// this function assumes the caller holds the cursorMu lock.
+func (d *datatable) nextMatch(step int) tea.Cmd {
+	if !d.getSearch().active() {
+		return nil
+	}
+
+	if !d.gotoMatch(d.cursor+step, step) {
+		return footerMsgCmd("No videos matching "+d.getSearch().query, 0)
+	}
+
+	return nil
+}
+
+// reloadRowsCmd loads the rows again for a changed filter, the cursor and the
+// start of the selection stay on their videos when those are still shown.
+func (d *datatable) reloadRowsCmd() tea.Cmd {
+	return func() tea.Msg {
+		d.selectModeMu.RLock()
+		startID := d.getIDAtIndex(d.selectModeStart)
+		d.selectModeMu.RUnlock()
+
+		cursorID := d.getCursorID()
+
+		if err := d.loadRows(); err != nil {
+			return errorMsg{err}
+		}
+
+		rows := d.getCopyOfRows()
+		indexOf := func(id string, fallback int) int {
+			if idx := slices.IndexFunc(rows, playingIDIndexFunc(id)); idx >= 0 {
+				return idx
+			}
+
+			return clamp(fallback, 0, max(len(rows)-1, 0))
+		}
+
+		d.selectModeMu.Lock()
+		defer d.selectModeMu.Unlock()
+
+		d.cursorMu.Lock()
+		defer d.cursorMu.Unlock()
+
+		d.cursor = indexOf(cursorID, d.cursor)
+		d.selectModeStart = indexOf(startID, d.selectModeStart)
+
+		return rowsReloadedMsg{}
+	}
+}
+
+// renderSearchBar shows the search input while typing, and the search in use
+// afterwards.
+func (d *datatable) renderSearchBar() string {
+	style := lipgloss.NewStyle().Width(d.width).Padding(0, dtCellPadding)
+
+	if d.searchInput.Focused() {
+		return style.Render(d.searchInput.View())
+	}
+
+	search := d.getSearch()
+	if !search.active() {
+		return ""
+	}
+
+	mode := "highlighted"
+	if search.filterOnly {
+		mode = "shown"
+	}
+
+	return style.Foreground(lipgloss.Color("214")).Render(runewidth.Truncate(
+		fmt.Sprintf(
+			"/%s  %d matches %s (n/N to jump, \\ to toggle filter, esc to clear)",
+			search.query,
+			len(search.matches),
+			mode,
+		),
+		d.width-style.GetHorizontalFrameSize(),
+		"…",
+	))
+}
+
+// highlightMatches truncates value to width rendering the bytes at matched
+// with highlight and the rest with style.
+func highlightMatches(
+	value string,
+	matched []int,
+	width int,
+	style, highlight lipgloss.Style,
+) string {
+	const tail = "…"
+
+	truncated := runewidth.Truncate(value, width, tail)
+
+	limit := len(truncated)
+	if truncated != value {
+		limit -= len(tail)
+	}
+
+	var s, run strings.Builder
+
+	runMatched := false
+	flush := func() {
+		if run.Len() == 0 {
+			return
+		}
+
+		if runMatched {
+			s.WriteString(highlight.Render(run.String()))
+		} else {
+			s.WriteString(style.Render(run.String()))
+		}
+
+		run.Reset()
+	}
+
+	for i, r := range truncated {
+		if isMatch := i < limit && slices.Contains(matched, i); isMatch != runMatched {
+			flush()
+			runMatched = isMatch
+		}
+
+		run.WriteRune(r)
+	}
+
+	flush()
+
+	return s.String()
+}
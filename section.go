@@ -13,9 +13,11 @@ type sectionChangedMsg struct {
 const (
 	sectionURLPrompt sectionType = iota
 	sectionDatatable
+	sectionUpNext
 )
 
-var sections = []sectionType{sectionURLPrompt, sectionDatatable} // nolint: gochecknoglobals
+// nolint: gochecknoglobals
+var sections = []sectionType{sectionURLPrompt, sectionDatatable, sectionUpNext}
 
 func (s sectionType) prev() sectionType {
 	prevIdx := (int(s) - 1 + len(sections)) % len(sections)
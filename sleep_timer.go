@@ -0,0 +1,278 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type sleepTimerMode int
+
+const (
+	sleepTimerOff sleepTimerMode = iota
+	sleepTimerAfterDuration
+	sleepTimerEndOfVideo
+	sleepTimerAfterVideos
+)
+
+const (
+	sleepTimerFadeDuration = 30 * time.Second
+	sleepTimerTickInterval = time.Second
+)
+
+type sleepTimerTickMsg struct{ tag int }
+
+type sleepTimerOption struct {
+	mode     sleepTimerMode
+	duration time.Duration
+	videos   int
+}
+
+type sleepTimer struct {
+	mu       sync.RWMutex
+	mode     sleepTimerMode
+	deadline time.Time
+	videos   int
+	tag      int
+	fading   bool
+	volume   float64
+}
+
+func sleepTimerTickCmd(tag int) tea.Cmd {
+	return tea.Tick(sleepTimerTickInterval, func(time.Time) tea.Msg {
+		return sleepTimerTickMsg{tag}
+	})
+}
+
+func sleepTimerPopupItems() []popupItem {
+	items := make([]popupItem, 0)
+
+	for _, minutes := range []int{15, 30, 45, 60, 90, 120} {
+		items = append(items, popupItem{
+			label: fmt.Sprintf("Stop after %d minutes", minutes),
+			value: sleepTimerOption{
+				mode:     sleepTimerAfterDuration,
+				duration: time.Duration(minutes) * time.Minute,
+			},
+		})
+	}
+
+	items = append(items, popupItem{
+		label: "Stop at the end of the current video",
+		value: sleepTimerOption{mode: sleepTimerEndOfVideo},
+	})
+
+	for _, videos := range []int{1, 2, 3, 5} {
+		items = append(items, popupItem{
+			label: fmt.Sprintf("Stop after %d more videos", videos),
+			value: sleepTimerOption{mode: sleepTimerAfterVideos, videos: videos},
+		})
+	}
+
+	return append(items, popupItem{label: "Cancel sleep timer", value: sleepTimerOption{}})
+}
+
+// setSleepTimer replaces the current sleep timer, any fade in progress is
+// cancelled and the volume restored.
+func (p *player) setSleepTimer(opt sleepTimerOption) tea.Cmd {
+	p.restoreSleepVolume()
+
+	p.sleep.mu.Lock()
+	p.sleep.mode = opt.mode
+	p.sleep.deadline = time.Now().Add(opt.duration)
+	p.sleep.videos = opt.videos
+	p.sleep.tag++
+	tag := p.sleep.tag
+	p.sleep.mu.Unlock()
+
+	if opt.mode == sleepTimerOff {
+		return footerMsgCmd("Sleep timer cancelled", 0)
+	}
+
+	return tea.Batch(sleepTimerTickCmd(tag), footerMsgCmd("Sleep timer set", 0))
+}
+
+func (p *player) isSleepTimerTag(tag int) bool {
+	p.sleep.mu.RLock()
+	defer p.sleep.mu.RUnlock()
+
+	return p.sleep.mode != sleepTimerOff && p.sleep.tag == tag
+}
+
+// sleepRemaining returns the time left before playback is stopped, false is
+// returned when it is not known yet (more videos to go or nothing playing).
+func (p *player) sleepRemaining() (time.Duration, bool) {
+	p.sleep.mu.RLock()
+	defer p.sleep.mu.RUnlock()
+
+	switch p.sleep.mode {
+	case sleepTimerAfterDuration:
+		return max(time.Until(p.sleep.deadline), 0), true
+	case sleepTimerAfterVideos:
+		if p.sleep.videos > 0 {
+			return 0, false
+		}
+
+		fallthrough
+	case sleepTimerEndOfVideo:
+		if p.getPlayingFilename() == "" {
+			return 0, false
+		}
+
+		return p.getRemainingTime(), true
+	case sleepTimerOff:
+	}
+
+	return 0, false
+}
+
+func (p *player) sleepTimerLabel() string {
+	p.sleep.mu.RLock()
+	mode, videos := p.sleep.mode, p.sleep.videos
+	p.sleep.mu.RUnlock()
+
+	switch mode {
+	case sleepTimerAfterDuration:
+		remaining, _ := p.sleepRemaining()
+		return "SLEEP " + formatPlaytime(remaining)
+	case sleepTimerAfterVideos:
+		if videos > 0 {
+			return fmt.Sprintf("SLEEP +%d", videos)
+		}
+
+		return "SLEEP END"
+	case sleepTimerEndOfVideo:
+		return "SLEEP END"
+	case sleepTimerOff:
+	}
+
+	return ""
+}
+
+func renderSleepTimer(label string) string {
+	if label == "" {
+		return ""
+	}
+
+	return lipgloss.NewStyle().
+		Bold(true).
+		Padding(0, 1).
+		Background(lipgloss.Color("24")).
+		Foreground(lipgloss.Color("231")).
+		Render(label)
+}
+
+// consumeSleepFinish is called when a video finished playing, it returns true
+// when the sleep timer wants playback to stop instead of moving on.
+func (p *player) consumeSleepFinish() bool {
+	p.sleep.mu.Lock()
+	defer p.sleep.mu.Unlock()
+
+	switch p.sleep.mode {
+	case sleepTimerEndOfVideo:
+		return true
+	case sleepTimerAfterVideos:
+		if p.sleep.videos == 0 {
+			return true
+		}
+
+		p.sleep.videos--
+	case sleepTimerOff, sleepTimerAfterDuration:
+	}
+
+	return false
+}
+
+func (p *player) isSleepFading() bool {
+	p.sleep.mu.RLock()
+	defer p.sleep.mu.RUnlock()
+
+	return p.sleep.fading
+}
+
+func (p *player) restoreSleepVolume() {
+	p.sleep.mu.Lock()
+	fading, volume := p.sleep.fading, p.sleep.volume
+	p.sleep.fading = false
+	p.sleep.mu.Unlock()
+
+	if !fading || !p.isRunning() {
+		return
+	}
+
+	if err := p.sendMPVCommand("set_property", "volume", volume); err != nil {
+		slog.Error("failed to restore volume", slog.String("error", err.Error()))
+	}
+}
+
+func (p *player) fadeSleepVolume(remaining time.Duration) {
+	if !p.isRunning() || p.getPlaying() != playingStatusPlaying {
+		return
+	}
+
+	p.sleep.mu.Lock()
+	if !p.sleep.fading {
+		p.sleep.fading = true
+		p.sleep.volume = p.getVolume()
+	}
+	volume := p.sleep.volume * float64(remaining) / float64(sleepTimerFadeDuration)
+	p.sleep.mu.Unlock()
+
+	if err := p.sendMPVCommand("set_property", "volume", volume); err != nil {
+		slog.Error("failed to fade volume", slog.String("error", err.Error()))
+	}
+}
+
+// expireSleepTimer pauses playback, records the position in mpv's watch later
+// config and turns the sleep timer off.
+func (p *player) expireSleepTimer() tea.Msg {
+	if p.isRunning() {
+		if err := p.sendMPVCommand("set_property", "pause", true); err != nil {
+			return errorMsg{fmt.Errorf("failed to pause for sleep timer: %w", err)}
+		}
+
+		if err := p.sendMPVCommand("write-watch-later-config"); err != nil {
+			return errorMsg{fmt.Errorf("failed to record playback position: %w", err)}
+		}
+	}
+
+	p.restoreSleepVolume()
+
+	p.sleep.mu.Lock()
+	p.sleep.mode = sleepTimerOff
+	p.sleep.mu.Unlock()
+
+	slog.Info("sleep timer expired", slog.Duration("position", p.getPlaytime()))
+
+	const msgDelay = 10 * time.Second
+
+	return footerMsgCmd("Sleep timer expired, playback paused", msgDelay)()
+}
+
+func (p *player) sleepTimerTick() tea.Msg {
+	remaining, known := p.sleepRemaining()
+	if !known {
+		return nil
+	}
+
+	p.sleep.mu.RLock()
+	mode := p.sleep.mode
+	p.sleep.mu.RUnlock()
+
+	switch {
+	case mode == sleepTimerAfterDuration && remaining <= 0:
+		return p.expireSleepTimer()
+	case remaining <= 0:
+		// time-remaining is not reported yet for a freshly loaded file
+	case remaining <= sleepTimerFadeDuration:
+		p.fadeSleepVolume(remaining)
+	default:
+		p.restoreSleepVolume()
+	}
+
+	return nil
+}
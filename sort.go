@@ -0,0 +1,211 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type sortKey int
+
+const (
+	sortManual sortKey = iota
+	sortName
+	sortAdded
+	sortUploaded
+	sortDuration
+	sortSize
+	sortWatched
+	sortKeyCount
+)
+
+func (k sortKey) String() string {
+	return [...]string{
+		"manual order",
+		"name",
+		"date added",
+		"upload date",
+		"duration",
+		"file size",
+		"watched",
+	}[k]
+}
+
+func (k sortKey) next() sortKey {
+	return (k + 1) % sortKeyCount
+}
+
+// column is the column sorted by, the manual order has none.
+func (k sortKey) column() column {
+	return [...]column{"", colName, colAdded, colUploaded, colDuration, colSize, colWatched}[k]
+}
+
+// tableSort orders the shown rows by a column, the manual order is kept for
+// rows comparing equal. It is not persisted so the manual order is what the
+// app starts with.
+type tableSort struct {
+	key  sortKey
+	desc bool
+}
+
+func (s tableSort) active() bool {
+	return s.key != sortManual
+}
+
+func (s tableSort) arrow() string {
+	if s.desc {
+		return "▼"
+	}
+
+	return "▲"
+}
+
+func parseSortNumber(s string) float64 {
+	n, _ := strconv.ParseFloat(s, 64)
+
+	return n
+}
+
+// compare orders a and b, rows missing the value go last in both directions.
+func (s tableSort) compare(a, b row) int {
+	col := s.key.column()
+	av, bv := a[col], b[col]
+
+	switch {
+	case av == "" && bv == "":
+		return 0
+	case av == "":
+		return 1
+	case bv == "":
+		return -1
+	}
+
+	var c int
+
+	switch s.key {
+	case sortName:
+		c = strings.Compare(strings.ToLower(av), strings.ToLower(bv))
+	case sortDuration, sortSize:
+		c = cmp.Compare(parseSortNumber(av), parseSortNumber(bv))
+	case sortWatched:
+		// unwatched first
+		isWatched := func(v string) int {
+			if v == isWatchedYes {
+				return 1
+			}
+
+			return 0
+		}
+		c = cmp.Compare(isWatched(av), isWatched(bv))
+	case sortManual, sortAdded, sortUploaded, sortKeyCount:
+		c = strings.Compare(av, bv)
+	}
+
+	if s.desc {
+		return -c
+	}
+
+	return c
+}
+
+func (s tableSort) apply(rows []row) {
+	if s.active() {
+		slices.SortStableFunc(rows, s.compare)
+	}
+}
+
+func (d *datatable) getSort() tableSort {
+	d.rowMu.RLock()
+	defer d.rowMu.RUnlock()
+
+	return d.sort
+}
+
+// shownColumns are the columns of the table, the column sorted by is shown
+// after the name when it is not one of them.
+func (d *datatable) shownColumns(s tableSort) []column {
+	col := s.key.column()
+	if col == "" || slices.Contains(d.columns, col) {
+		return d.columns
+	}
+
+	return slices.Insert(slices.Clone(d.columns), slices.Index(d.columns, colName)+1, col)
+}
+
+// colWidth is the width of col, an added sort column takes its room from the
+// name.
+func (d *datatable) colWidth(col column, columns []column) int {
+	const sortColWidth = 12
+
+	if !slices.Contains(d.columns, col) {
+		return sortColWidth
+	}
+
+	if col == colName && len(columns) > len(d.columns) {
+		return d.widths[colName] - sortColWidth
+	}
+
+	return d.widths[col]
+}
+
+// sortFooter tells what the rows are sorted by and how many go last for
+// missing the value, such as videos added before their upload date and
+// duration were stored.
+func (d *datatable) sortFooter(s tableSort) string {
+	footer := "Sorted by " + s.key.String()
+	if !s.active() {
+		return footer
+	}
+
+	footer = fmt.Sprintf("%s %s", footer, s.arrow())
+
+	missing := 0
+
+	for _, r := range d.getCopyOfRows() {
+		if r[s.key.column()] == "" {
+			missing++
+		}
+	}
+
+	if missing > 0 {
+		footer = fmt.Sprintf("%s, %d videos have no %s and go last", footer, missing, s.key)
+	}
+
+	return footer
+}
+
+func (d *datatable) setSortCmd(s tableSort) tea.Cmd {
+	return tea.Sequence(func() tea.Msg {
+		d.rowMu.Lock()
+		d.sort = s
+		d.rowMu.Unlock()
+
+		return d.reloadRowsCmd()()
+	}, func() tea.Msg {
+		return footerMsgCmd(d.sortFooter(s), 0)()
+	})
+}
+
+// cycleSortCmd sorts by the next column, after the last one the manual order
+// is back.
+func (d *datatable) cycleSortCmd() tea.Cmd {
+	s := d.getSort()
+	s.key = s.key.next()
+
+	return d.setSortCmd(s)
+}
+
+func (d *datatable) toggleSortDirectionCmd() tea.Cmd {
+	s := d.getSort()
+	if !s.active() {
+		return footerMsgCmd("Pick a column to sort by with s first", 0)
+	}
+
+	s.desc = !s.desc
+
+	return d.setSortCmd(s)
+}
@@ -123,7 +123,7 @@ func (d *status) Update(msg tea.Msg) (*status, tea.Cmd) {
 		filename := filepath.Base(msg.Filename)
 		percent := downloaded / total
 		cmds = append(cmds, d.progress.SetPercent(percent), d.filename.updateText(filename))
-	case deletedRowMsg:
+	case deletedRowMsg, purgedVideosMsg:
 		cmds = append(cmds, d.getFreeSpaceCmd())
 	case finishDownloadMsg:
 		d.status = downloadStatusFinished
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	errUnsupportedSubtitle = errors.New("unsupported subtitle format")
+	subtitleTagRe          = regexp.MustCompile(`<[^>]*>`) // nolint: gochecknoglobals
+)
+
+type subtitleCue struct {
+	start time.Duration
+	text  string
+}
+
+// parseCueTimestamp parses a .vtt (00:01:02.345 or 01:02.345) or .srt
+// (00:01:02,345) timestamp.
+func parseCueTimestamp(s string) (time.Duration, error) {
+	const maxParts = 3
+
+	s = strings.ReplaceAll(strings.TrimSpace(s), ",", ".")
+	parts := strings.Split(s, ":")
+
+	if len(parts) < 2 || len(parts) > maxParts {
+		return 0, fmt.Errorf("invalid cue timestamp %q", s)
+	}
+
+	const minutesPerHour = 60
+
+	minutes := 0
+
+	for _, part := range parts[:len(parts)-1] {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return 0, fmt.Errorf("invalid cue timestamp %q", s)
+		}
+
+		minutes = minutes*minutesPerHour + n
+	}
+
+	seconds, err := strconv.ParseFloat(parts[len(parts)-1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cue timestamp %q", s)
+	}
+
+	return time.Duration(minutes)*time.Minute + time.Duration(seconds*float64(time.Second)), nil
+}
+
+func cleanCueLine(line string) string {
+	return strings.TrimSpace(html.UnescapeString(subtitleTagRe.ReplaceAllString(line, "")))
+}
+
+// parseSubtitleFile reads the cues of a .vtt or .srt file.
+func parseSubtitleFile(path string) ([]subtitleCue, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".vtt", ".srt":
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnsupportedSubtitle, path)
+	}
+
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() // nolint:errcheck
+
+	return parseSubtitles(f)
+}
+
+// parseEmbeddedSubtitle converts the nth subtitle stream of a video file to
+// WebVTT with ffmpeg and reads its cues.
+func parseEmbeddedSubtitle(ctx context.Context, videoPath string, n int) ([]subtitleCue, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, "ffmpeg", // #nosec G204
+		"-hide_banner",
+		"-loglevel", "error",
+		"-nostdin",
+		"-i", videoPath,
+		"-map", fmt.Sprintf("0:s:%d", n),
+		"-f", "webvtt",
+		"pipe:1",
+	)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+
+		return nil, err
+	}
+
+	return parseSubtitles(&stdout)
+}
+
+// parseSubtitles reads the cues of .vtt or .srt subtitles. Automatic captions
+// repeat the previous line in every cue so lines already seen in the previous
+// cue are dropped.
+func parseSubtitles(r io.Reader) ([]subtitleCue, error) {
+	var (
+		cues     []subtitleCue
+		cue      *subtitleCue
+		lines    []string
+		previous []string
+	)
+
+	flush := func() {
+		if cue == nil {
+			return
+		}
+
+		text := make([]string, 0, len(lines))
+
+		for _, line := range lines {
+			if line != "" && !slices.Contains(previous, line) {
+				text = append(text, line)
+			}
+		}
+
+		if len(text) != 0 {
+			cue.text = strings.Join(text, " ")
+			cues = append(cues, *cue)
+		}
+
+		previous, lines, cue = lines, nil, nil
+	}
+
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "":
+			flush()
+		case strings.Contains(line, "-->"):
+			flush()
+
+			start, err := parseCueTimestamp(strings.Fields(line)[0])
+			if err != nil {
+				return nil, err
+			}
+
+			cue = &subtitleCue{start: start}
+		case cue != nil:
+			lines = append(lines, cleanCueLine(line))
+		}
+	}
+
+	flush()
+
+	return cues, scanner.Err()
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/linnovs/ytqueue/database"
+)
+
+const (
+	subtitleSearchLimit = 200
+	// subtitlesIndexedSettingKey is set once the subtitles stored before the
+	// search existed are indexed.
+	subtitlesIndexedSettingKey = "subtitles_indexed"
+)
+
+// indexStoredSubtitlesCmd indexes the subtitles downloaded before the search
+// existed, once.
+func (d *datatable) indexStoredSubtitlesCmd() tea.Cmd {
+	return func() tea.Msg {
+		indexed, err := d.datastore.getSetting(d.getCtx(), subtitlesIndexedSettingKey)
+		if err != nil {
+			return errorMsg{fmt.Errorf("failed to load subtitle index state: %w", err)}
+		}
+
+		if indexed != "" {
+			return nil
+		}
+
+		videos, err := d.datastore.indexStoredSubtitles(d.getCtx())
+		if err != nil {
+			return errorMsg{fmt.Errorf("failed to index subtitles: %w", err)}
+		}
+
+		if err := d.datastore.setSetting(d.getCtx(), subtitlesIndexedSettingKey, "1"); err != nil {
+			return errorMsg{fmt.Errorf("failed to save subtitle index state: %w", err)}
+		}
+
+		if videos == 0 {
+			return nil
+		}
+
+		return footerMsgCmd(fmt.Sprintf("Indexed the subtitles of %d videos", videos), 0)()
+	}
+}
+
+// subtitleSearchPopupCmd asks for the words to look up in the indexed
+// subtitles of the library.
+func (d *datatable) subtitleSearchPopupCmd() tea.Cmd {
+	form := newFormPopup(
+		"Search subtitles",
+		[]formField{{label: "Words spoken in the video"}},
+		func(values []string) tea.Cmd {
+			return d.searchSubtitlesCmd(values[0])
+		},
+	)
+
+	return openFormPopupCmd(form)
+}
+
+// searchSubtitlesCmd lists the matching subtitle lines across the library,
+// choosing one plays its video from the matched cue.
+func (d *datatable) searchSubtitlesCmd(query string) tea.Cmd {
+	return func() tea.Msg {
+		if strings.TrimSpace(query) == "" {
+			return nil
+		}
+
+		matches, err := d.datastore.searchSubtitles(d.getCtx(), query, subtitleSearchLimit)
+		if err != nil {
+			return errorMsg{fmt.Errorf("failed to search subtitles: %w", err)}
+		}
+
+		if len(matches) == 0 {
+			return footerMsgCmd(fmt.Sprintf("No subtitles matching %q", query), 0)()
+		}
+
+		items := make([]popupItem, 0, len(matches))
+		for _, match := range matches {
+			start := time.Duration(match.StartTime * float64(time.Second))
+			name := strings.TrimSuffix(match.Name, filepath.Ext(match.Name))
+			items = append(items, popupItem{
+				label: fmt.Sprintf("%s  %s  %s", formatPlaytime(start), match.Snippet, name),
+				value: match,
+			})
+		}
+
+		title := fmt.Sprintf("Subtitles matching %q (%d)", query, len(matches))
+
+		return openPopupMsg{newPopup(title, items, func(item popupItem) tea.Cmd {
+			match, _ := item.value.(database.SearchSubtitleCuesRow)
+			start := time.Duration(match.StartTime * float64(time.Second))
+
+			return d.playAtCmd(fmt.Sprint(match.VideoID), start)
+		})}
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// subtitleArgs returns the yt-dlp arguments requesting the configured
+// subtitle languages, no subtitles are requested without languages.
+func (d *downloader) subtitleArgs() []string {
+	if len(d.subLanguages) == 0 {
+		return nil
+	}
+
+	args := []string{"--write-subs", "--sub-langs", strings.Join(d.subLanguages, ",")}
+
+	if d.autoSubs {
+		args = append(args, "--write-auto-subs")
+	}
+
+	if d.embedSubs {
+		args = append(args, "--embed-subs")
+	}
+
+	return args
+}
+
+// downloadedSubtitles lists the subtitles written next to the video file
+// using yt-dlp's default <name>.<language>.<ext> naming.
+func (d *downloader) downloadedSubtitles(
+	videoPath string,
+	subtitles map[string]subtitleFile,
+) []downloadedSubtitle {
+	base := strings.TrimSuffix(videoPath, filepath.Ext(videoPath))
+	downloaded := make([]downloadedSubtitle, 0, len(subtitles))
+
+	for language, file := range subtitles {
+		if language == "live_chat" {
+			continue
+		}
+
+		s := downloadedSubtitle{language: language}
+		if !d.embedSubs {
+			s.path = base + "." + language + "." + file.Ext
+		}
+
+		downloaded = append(downloaded, s)
+	}
+
+	slices.SortFunc(downloaded, func(a, b downloadedSubtitle) int {
+		return strings.Compare(a.language, b.language)
+	})
+
+	return downloaded
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// watchedTag is not stored, in a filter it matches the watched videos.
+const watchedTag = "watched"
+
+var errInvalidTag = errors.New(`a tag cannot start with "-" or be named "watched"`)
+
+// parseTagChanges splits "talks -cooking" into the tags to add and remove.
+func parseTagChanges(input string) (add, remove []string, err error) {
+	for _, field := range strings.Fields(strings.ToLower(input)) {
+		tag, isRemove := strings.CutPrefix(field, "-")
+		if tag == "" || strings.HasPrefix(tag, "-") || tag == watchedTag {
+			return nil, nil, fmt.Errorf("%w: %s", errInvalidTag, field)
+		}
+
+		if isRemove {
+			remove = append(remove, tag)
+		} else {
+			add = append(add, tag)
+		}
+	}
+
+	return add, remove, nil
+}
+
+// applyTagChanges returns the tag column after adding and removing tags.
+func applyTagChanges(tags string, add, remove []string) string {
+	result := slices.DeleteFunc(strings.Fields(tags), func(tag string) bool {
+		return slices.Contains(remove, tag)
+	})
+	result = append(result, add...)
+	slices.Sort(result)
+
+	return strings.Join(slices.Compact(result), " ")
+}
+
+// tagFilter keeps the rows having every included tag and none of the excluded
+// ones, e.g. "talks -watched".
+type tagFilter struct {
+	expr    string
+	include []string
+	exclude []string
+}
+
+func parseTagFilter(expr string) tagFilter {
+	f := tagFilter{expr: strings.Join(strings.Fields(strings.ToLower(expr)), " ")}
+
+	for _, field := range strings.Fields(f.expr) {
+		if tag, ok := strings.CutPrefix(field, "-"); ok {
+			f.exclude = append(f.exclude, tag)
+		} else {
+			f.include = append(f.include, field)
+		}
+	}
+
+	return f
+}
+
+func (f tagFilter) active() bool {
+	return f.expr != ""
+}
+
+func (f tagFilter) matches(r row) bool {
+	tags := strings.Fields(r[colTags])
+	if r[colWatched] == isWatchedYes {
+		tags = append(tags, watchedTag)
+	}
+
+	for _, tag := range f.include {
+		if !slices.Contains(tags, tag) {
+			return false
+		}
+	}
+
+	for _, tag := range f.exclude {
+		if slices.Contains(tags, tag) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (d *datatable) getTagFilter() tagFilter {
+	d.rowMu.RLock()
+	defer d.rowMu.RUnlock()
+
+	return d.filter
+}
+
+// editTagsPopupCmd opens the form adding and removing tags of the video at
+// cursor or the selected videos.
+func (d *datatable) editTagsPopupCmd(cursor int) tea.Cmd {
+	return func() tea.Msg {
+		ids := make([]string, 0)
+		title := ""
+
+		for _, row := range d.targetRows(cursor) {
+			ids = append(ids, row[colID])
+			title = fmt.Sprintf("Tags: %s [%s]", row[colName], row[colTags])
+		}
+
+		if len(ids) == 0 {
+			return nil
+		}
+
+		if len(ids) > 1 {
+			title = fmt.Sprintf("Tags: %d videos", len(ids))
+		}
+
+		form := newFormPopup(title, []formField{{label: "Tags to add, -tag to remove"}},
+			func(values []string) tea.Cmd {
+				return d.tagRowsCmd(ids, values[0])
+			},
+		)
+
+		return openFormPopupCmd(form)()
+	}
+}
+
+func (d *datatable) tagRowsCmd(ids []string, input string) tea.Cmd {
+	return func() tea.Msg {
+		add, remove, err := parseTagChanges(input)
+		if err != nil {
+			return errorMsg{err}
+		}
+
+		if len(add) == 0 && len(remove) == 0 {
+			return nil
+		}
+
+		if err := d.datastore.tagVideos(d.getCtx(), ids, add, remove); err != nil {
+			return errorMsg{fmt.Errorf("failed to update tags: %w", err)}
+		}
+
+		rows := d.getCopyOfRows()
+
+		for i, r := range rows {
+			if slices.Contains(ids, r[colID]) {
+				rows[i] = maps.Clone(r)
+				rows[i][colTags] = applyTagChanges(r[colTags], add, remove)
+			}
+		}
+
+		d.setRows(rows)
+
+		if len(ids) == 1 {
+			return footerMsgCmd("Updated tags", 0)()
+		}
+
+		return footerMsgCmd(fmt.Sprintf("Updated tags of %d videos", len(ids)), 0)()
+	}
+}
+
+// tagFilterPopupCmd opens the form editing the tag filter, an empty filter
+// shows all videos again.
+func (d *datatable) tagFilterPopupCmd() tea.Cmd {
+	form := newFormPopup("Filter by tags", []formField{{
+		label: `Tags to show, -tag to hide, "watched" for watched videos`,
+		value: d.getTagFilter().expr,
+	}}, func(values []string) tea.Cmd {
+		d.rowMu.Lock()
+		d.filter = parseTagFilter(values[0])
+		d.rowMu.Unlock()
+
+		return d.refreshRowsCmd()
+	})
+
+	return openFormPopupCmd(form)
+}
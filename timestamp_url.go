@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timestampedURL returns rawURL pointing at the given playback position using
+// the convention of the site, a media fragment is used for unknown sites.
+func timestampedURL(rawURL string, at time.Duration) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	seconds := int(at.Seconds())
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+
+	switch {
+	case host == "youtu.be":
+		query := u.Query()
+		query.Set("t", fmt.Sprint(seconds))
+		u.RawQuery = query.Encode()
+	case host == "youtube.com" || strings.HasSuffix(host, ".youtube.com"):
+		query := u.Query()
+		query.Set("t", fmt.Sprintf("%ds", seconds))
+		u.RawQuery = query.Encode()
+	default:
+		u.Fragment = fmt.Sprintf("t=%d", seconds)
+	}
+
+	return u.String()
+}
+
+// parseURLTimestamp parses the value of a t or start parameter, it is either
+// plain seconds or a duration like 1h2m3s.
+func parseURLTimestamp(value string) (time.Duration, bool) {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, seconds > 0
+	}
+
+	d, err := time.ParseDuration(value)
+
+	return d, err == nil && d > 0
+}
+
+// splitURLTimestamp removes the playback position (t=, start= or #t=) from
+// rawURL and returns it separately.
+func splitURLTimestamp(rawURL string) (string, time.Duration) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL, 0
+	}
+
+	var start time.Duration
+
+	query := u.Query()
+	for _, param := range []string{"t", "start"} {
+		if !query.Has(param) {
+			continue
+		}
+
+		if d, ok := parseURLTimestamp(query.Get(param)); ok && start == 0 {
+			start = d
+		}
+
+		query.Del(param)
+	}
+
+	u.RawQuery = query.Encode()
+
+	if value, ok := strings.CutPrefix(u.Fragment, "t="); ok {
+		if d, ok := parseURLTimestamp(value); ok && start == 0 {
+			start = d
+		}
+
+		u.Fragment = ""
+	}
+
+	return u.String(), start
+}
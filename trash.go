@@ -0,0 +1,378 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/adrg/xdg"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/linnovs/ytqueue/database"
+)
+
+// Deleted videos are moved into the trash of the freedesktop.org trash
+// specification, so file managers can restore them as well. A file on another
+// filesystem than the home trash goes to the .Trash-$uid directory at the top
+// of its mount.
+const (
+	trashDirPerm      = 0o700
+	restoreDirPerm    = 0o755
+	trashInfoPerm     = 0o600
+	trashInfoExt      = ".trashinfo"
+	trashDeletionDate = "2006-01-02T15:04:05"
+)
+
+var errTrashedFileGone = errors.New("file is no longer in the trash")
+
+func homeTrashDir() string {
+	return filepath.Join(xdg.DataHome, "Trash")
+}
+
+// moveToTrash moves the file at path into the trash and returns where it is
+// now.
+func moveToTrash(path string) (string, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := os.Lstat(path); err != nil {
+		return "", err
+	}
+
+	trashPath, err := trashInto(homeTrashDir(), path)
+	if !errors.Is(err, syscall.EXDEV) {
+		return trashPath, err
+	}
+
+	topDir, err := mountTopDir(path)
+	if err != nil {
+		return "", err
+	}
+
+	return trashInto(filepath.Join(topDir, fmt.Sprintf(".Trash-%d", os.Getuid())), path)
+}
+
+// trashInto moves path into the trash directory dir, the .trashinfo file is
+// created first to claim a name not used by another trashed file.
+func trashInto(dir, path string) (string, error) {
+	filesDir, infoDir := filepath.Join(dir, "files"), filepath.Join(dir, "info")
+
+	for _, d := range []string{filesDir, infoDir} {
+		if err := os.MkdirAll(d, trashDirPerm); err != nil {
+			return "", err
+		}
+	}
+
+	base := filepath.Base(path)
+	ext := filepath.Ext(base)
+	stem := strings.TrimSuffix(base, ext)
+
+	for n := 1; ; n++ {
+		name := base
+		if n > 1 {
+			name = fmt.Sprintf("%s.%d%s", stem, n, ext)
+		}
+
+		infoPath := filepath.Join(infoDir, name+trashInfoExt)
+		trashPath := filepath.Join(filesDir, name)
+
+		created, err := writeTrashInfo(infoPath, path)
+		if err != nil {
+			return "", err
+		}
+
+		if !created {
+			continue
+		}
+
+		// a file left without its .trashinfo still holds the name
+		if _, err := os.Lstat(trashPath); err == nil {
+			_ = os.Remove(infoPath)
+
+			continue
+		}
+
+		if err := os.Rename(path, trashPath); err != nil {
+			_ = os.Remove(infoPath)
+
+			return "", err
+		}
+
+		return trashPath, nil
+	}
+}
+
+// writeTrashInfo creates the .trashinfo file of path, created is false when
+// the file already exists.
+func writeTrashInfo(infoPath, path string) (created bool, err error) {
+	f, err := os.OpenFile(infoPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, trashInfoPerm)
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	_, err = fmt.Fprintf(
+		f,
+		"[Trash Info]\nPath=%s\nDeletionDate=%s\n",
+		(&url.URL{Path: path}).EscapedPath(),
+		time.Now().Format(trashDeletionDate),
+	)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		_ = os.Remove(infoPath)
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+// mountTopDir returns the top directory of the mount path is on.
+func mountTopDir(path string) (string, error) {
+	device := func(p string) (uint64, error) {
+		info, err := os.Stat(p)
+		if err != nil {
+			return 0, err
+		}
+
+		stat, ok := info.Sys().(*syscall.Stat_t)
+		if !ok {
+			return 0, fmt.Errorf("no device of %s", p)
+		}
+
+		return uint64(stat.Dev), nil // nolint:unconvert
+	}
+
+	dir := filepath.Dir(path)
+
+	dev, err := device(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return dir, nil
+		}
+
+		// a parent that cannot be read is taken as past the mount
+		if parentDev, err := device(parent); err != nil || parentDev != dev {
+			return dir, nil // nolint:nilerr
+		}
+
+		dir = parent
+	}
+}
+
+func trashInfoPath(trashPath string) string {
+	dir := filepath.Dir(filepath.Dir(trashPath))
+
+	return filepath.Join(dir, "info", filepath.Base(trashPath)+trashInfoExt)
+}
+
+// restoreFromTrash moves a trashed file back to path.
+func restoreFromTrash(trashPath, path string) error {
+	if _, err := os.Lstat(trashPath); errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: %s", errTrashedFileGone, filepath.Base(path))
+	}
+
+	if _, err := os.Lstat(path); err == nil {
+		return fmt.Errorf("%s already exists", path)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModeDir|restoreDirPerm); err != nil {
+		return err
+	}
+
+	if err := os.Rename(trashPath, path); err != nil {
+		return err
+	}
+
+	if err := os.Remove(trashInfoPath(trashPath)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// purgeFromTrash deletes a trashed file for good, one already emptied from
+// the trash is fine.
+func purgeFromTrash(trashPath string) error {
+	for _, p := range []string{trashPath, trashInfoPath(trashPath)} {
+		if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var errTrashFile = errors.New("failed to move video file to trash")
+
+type (
+	restoredVideosMsg struct {
+		names []string
+		err   error
+	}
+	purgedVideosMsg struct{ count int }
+	emptyTrashItem  struct{}
+	trashAction     int
+)
+
+const (
+	trashActionRestore trashAction = iota + 1
+	trashActionPurge
+)
+
+// trashRowFile moves the file of r into the trash, the path is empty when
+// there was no file to move.
+func trashRowFile(r row) (trashedFile, error) {
+	from := filepath.Join(r[colLocation], r[colName])
+
+	trashPath, err := moveToTrash(from)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return trashedFile{}, fmt.Errorf("%w %s: %w", errTrashFile, r[colName], err)
+	}
+
+	return trashedFile{videoID: r[colID], from: from, path: trashPath}, nil
+}
+
+// trashVideos hides the videos whose files were moved to the trash, the files
+// are put back when that fails.
+func (d *datatable) trashVideos(files []trashedFile) error {
+	err := d.datastore.trashVideos(d.getCtx(), files)
+	if err == nil {
+		return nil
+	}
+
+	for _, file := range files {
+		if file.path != "" {
+			_ = restoreFromTrash(file.path, file.from)
+		}
+	}
+
+	return fmt.Errorf("failed to move videos to trash: %w", err)
+}
+
+// restoreVideosCmd moves the files of the trashed videos back and shows the
+// videos again, ones restored or purged meanwhile are skipped.
+func (d *datatable) restoreVideosCmd(ids []int64) tea.Cmd {
+	return func() tea.Msg {
+		names, err := d.datastore.restoreVideos(d.getCtx(), ids)
+
+		return restoredVideosMsg{names: names, err: err}
+	}
+}
+
+func restoredFooterStr(names []string) string {
+	switch len(names) {
+	case 0:
+		return "Nothing left to restore"
+	case 1:
+		return "Restored video: " + names[0]
+	}
+
+	return fmt.Sprintf("Restored %d videos", len(names))
+}
+
+// purgeVideosCmd deletes trashed videos and their files for good.
+func (d *datatable) purgeVideosCmd(videos []database.Video) tea.Cmd {
+	return func() tea.Msg {
+		for _, video := range videos {
+			if video.TrashPath != nil {
+				if err := purgeFromTrash(*video.TrashPath); err != nil {
+					return errorMsg{fmt.Errorf("failed to delete %s: %w", video.Name, err)}
+				}
+			}
+
+			if err := d.datastore.deleteVideo(d.getCtx(), video.ID); err != nil {
+				return errorMsg{fmt.Errorf("failed to delete %s: %w", video.Name, err)}
+			}
+		}
+
+		return purgedVideosMsg{len(videos)}
+	}
+}
+
+// trashPopupCmd lists the trashed videos, newest first, to restore or delete
+// for good.
+func (d *datatable) trashPopupCmd() tea.Cmd {
+	return func() tea.Msg {
+		videos, err := d.datastore.getTrashedVideos(d.getCtx())
+		if err != nil {
+			return errorMsg{fmt.Errorf("failed to load trash: %w", err)}
+		}
+
+		if len(videos) == 0 {
+			return footerMsgCmd("Trash is empty", 0)()
+		}
+
+		items := make([]popupItem, 0, len(videos)+1) // and empty trash
+
+		for _, video := range videos {
+			items = append(items, popupItem{
+				label: fmt.Sprintf("%s  %s", formatCreatedAt(video.DeletedAt), video.Name),
+				value: video,
+			})
+		}
+
+		items = append(items, popupItem{label: "- Empty trash", value: emptyTrashItem{}})
+		title := fmt.Sprintf("Trash (%d videos)", len(videos))
+
+		return openPopupMsg{newPopup(title, items, func(item popupItem) tea.Cmd {
+			switch value := item.value.(type) {
+			case database.Video:
+				return d.trashedVideoPopupCmd(value)
+			case emptyTrashItem:
+				return d.emptyTrashPopupCmd(videos)
+			}
+
+			return nil
+		})}
+	}
+}
+
+func (d *datatable) trashedVideoPopupCmd(video database.Video) tea.Cmd {
+	items := []popupItem{
+		{label: "Cancel"},
+		{label: "Restore", value: trashActionRestore},
+		{label: "Delete permanently", value: trashActionPurge},
+	}
+
+	return openPopupCmd(newPopup(video.Name, items, func(item popupItem) tea.Cmd {
+		switch item.value {
+		case trashActionRestore:
+			return d.restoreVideosCmd([]int64{video.ID})
+		case trashActionPurge:
+			return d.purgeVideosCmd([]database.Video{video})
+		}
+
+		return nil
+	}))
+}
+
+func (d *datatable) emptyTrashPopupCmd(videos []database.Video) tea.Cmd {
+	title := fmt.Sprintf("Delete %d videos in the trash permanently?", len(videos))
+	items := []popupItem{{label: "Cancel"}, {label: "Delete", value: trashActionPurge}}
+
+	return openPopupCmd(newPopup(title, items, func(item popupItem) tea.Cmd {
+		if item.value == nil {
+			return nil
+		}
+
+		return d.purgeVideosCmd(videos)
+	}))
+}
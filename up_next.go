@@ -0,0 +1,213 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/list"
+	"github.com/mattn/go-runewidth"
+)
+
+type upNextItem struct {
+	id   string
+	name string
+}
+
+// upNextQueue is the ephemeral list of videos to play next, it is shared by
+// the datatable which fills and consumes it and the upNext panel.
+type upNextQueue struct {
+	mu    sync.RWMutex
+	items []upNextItem
+}
+
+func newUpNextQueue() *upNextQueue {
+	return &upNextQueue{items: make([]upNextItem, 0)}
+}
+
+func (q *upNextQueue) push(items ...upNextItem) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.items = append(q.items, items...)
+}
+
+func (q *upNextQueue) pop() (upNextItem, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.items) == 0 {
+		return upNextItem{}, false
+	}
+
+	item := q.items[0]
+	q.items = q.items[1:]
+
+	return item, true
+}
+
+func (q *upNextQueue) remove(idx int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if idx < 0 || idx >= len(q.items) {
+		return
+	}
+
+	q.items = slices.Delete(q.items, idx, idx+1)
+}
+
+// move swaps the item at idx with its neighbour n steps away and returns the
+// new index of the item.
+func (q *upNextQueue) move(idx, n int) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if idx < 0 || idx >= len(q.items) {
+		return idx
+	}
+
+	next := clamp(idx+n, 0, len(q.items)-1)
+	q.items[idx], q.items[next] = q.items[next], q.items[idx]
+
+	return next
+}
+
+func (q *upNextQueue) clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.items = q.items[:0]
+}
+
+func (q *upNextQueue) list() []upNextItem {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	return slices.Clone(q.items)
+}
+
+type upNext struct {
+	width     int
+	queue     *upNextQueue
+	cursor    int
+	isFocused bool
+	keymap    upNextKeymap
+	style     lipgloss.Style
+	header    lipgloss.Style
+	count     lipgloss.Style
+}
+
+func newUpNext(queue *upNextQueue) *upNext {
+	return &upNext{
+		queue:  queue,
+		keymap: newUpNextKeymap(),
+		style:  lipgloss.NewStyle().BorderStyle(lipgloss.RoundedBorder()),
+		header: lipgloss.NewStyle().Bold(true).Padding(0, 1).
+			Background(lipgloss.Color("166")).
+			Foreground(lipgloss.Color("231")).
+			SetString("UP NEXT"),
+		count: lipgloss.NewStyle().Bold(true).Padding(0, 1).Background(lipgloss.Color("208")),
+	}
+}
+
+func (u *upNext) Init() tea.Cmd {
+	return nil
+}
+
+func (u *upNext) Update(msg tea.Msg) (*upNext, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		u.width = msg.Width - u.style.GetHorizontalFrameSize()
+	case sectionChangedMsg:
+		u.isFocused = msg.section == sectionUpNext
+		if u.isFocused {
+			u.style = u.style.BorderForeground(activeBorderColor)
+		} else {
+			u.style = u.style.UnsetBorderForeground()
+		}
+	case tea.KeyMsg:
+		if !u.isFocused {
+			break
+		}
+
+		u.keyMsgHandler(msg)
+	}
+
+	return u, nil
+}
+
+func (u *upNext) keyMsgHandler(msg tea.KeyMsg) {
+	switch {
+	case key.Matches(msg, u.keymap.lineUp):
+		u.cursor--
+	case key.Matches(msg, u.keymap.lineDown):
+		u.cursor++
+	case key.Matches(msg, u.keymap.moveUp):
+		u.cursor = u.queue.move(u.cursor, -1)
+	case key.Matches(msg, u.keymap.moveDown):
+		u.cursor = u.queue.move(u.cursor, 1)
+	case key.Matches(msg, u.keymap.remove):
+		u.queue.remove(u.cursor)
+	case key.Matches(msg, u.keymap.clear):
+		u.queue.clear()
+	}
+
+	u.cursor = clamp(u.cursor, 0, len(u.queue.list())-1)
+}
+
+func (u *upNext) renderList(items []upNextItem) string {
+	const (
+		maxItems        = 5
+		enumeratorWidth = 6
+	)
+
+	start := clamp(u.cursor-maxItems+1, 0, max(len(items)-maxItems, 0))
+	end := min(start+maxItems, len(items))
+
+	l := list.New().Enumerator(func(_ list.Items, i int) string {
+		return fmt.Sprintf("%d.", start+i+1)
+	})
+
+	for i := start; i < end; i++ {
+		name := runewidth.Truncate(items[i].name, u.width-enumeratorWidth, "…")
+		style := lipgloss.NewStyle()
+
+		if u.isFocused && i == u.cursor {
+			style = style.Background(activeBorderColor).Foreground(lipgloss.Color("229"))
+		}
+
+		l.Item(style.Render(name))
+	}
+
+	if end < len(items) {
+		more := fmt.Sprintf("⋮ %d more", len(items)-end)
+
+		return lipgloss.JoinVertical(lipgloss.Left, l.String(), more)
+	}
+
+	return l.String()
+}
+
+func (u *upNext) View() string {
+	items := u.queue.list()
+	if len(items) == 0 && !u.isFocused {
+		return ""
+	}
+
+	title := lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		u.header.Render(),
+		u.count.Render(fmt.Sprint(len(items))),
+	)
+
+	content := title
+	if len(items) != 0 {
+		content = lipgloss.JoinVertical(lipgloss.Left, title, u.renderList(items))
+	}
+
+	return u.style.Width(u.width).Render(content)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
@@ -14,7 +15,8 @@ import (
 )
 
 type submitURLMsg struct {
-	url string
+	url   string
+	start time.Duration
 }
 
 type urlPrompt struct {
@@ -58,9 +60,13 @@ func (p *urlPrompt) Init() tea.Cmd {
 	return tea.Batch(textinput.Blink, p.spinner.Tick)
 }
 
+// submitURLCmd submits url for download, a timestamp in it is kept aside as
+// the position to start playing from.
 func submitURLCmd(url string) tea.Cmd {
 	return func() tea.Msg {
-		return submitURLMsg{url}
+		url, start := splitURLTimestamp(url)
+
+		return submitURLMsg{url, start}
 	}
 }
 